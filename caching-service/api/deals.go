@@ -0,0 +1,283 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+// statusNames names the store.Deal* statuses, in order; the status filter
+// accepts either the name or the number.
+var statusNames = []string{"created", "funded", "in_progress", "completed", "disputed"}
+
+// Deal is a deal as returned by the API.
+type Deal struct {
+	store.Deal
+	StatusName string `json:"statusName"`
+}
+
+func newDeal(d store.Deal) Deal {
+	deal := Deal{Deal: d}
+	if d.Status >= 0 && d.Status < len(statusNames) {
+		deal.StatusName = statusNames[d.Status]
+	}
+	return deal
+}
+
+// DealPage is one page of a deals listing. NextCursor is empty on the last
+// page.
+type DealPage struct {
+	Deals      []Deal `json:"deals"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// DealDetail is a deal together with its event history.
+type DealDetail struct {
+	Deal
+	Events []store.DealEvent `json:"events"`
+}
+
+// errAmbiguousDeal is returned when an address is looked up without a chain
+// and it is in use on more than one.
+var errAmbiguousDeal = errors.New("deal exists on several chains; pass chain")
+
+// parseCommonFilters applies the chain, status and token query parameters.
+func parseCommonFilters(r *http.Request, dq *store.DealQuery) error {
+	q := r.URL.Query()
+	var err error
+	if dq.ChainID, err = parseChain(q.Get("chain")); err != nil {
+		return err
+	}
+	if v := q.Get("status"); v != "" {
+		status, err := parseStatus(v)
+		if err != nil {
+			return err
+		}
+		dq.Status = &status
+	}
+	if v := q.Get("token"); v != "" {
+		if dq.Token, err = parseAddress(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseStatus(v string) (int, error) {
+	for i, name := range statusNames {
+		if strings.EqualFold(v, name) {
+			return i, nil
+		}
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 || n >= len(statusNames) {
+		return 0, fmt.Errorf("invalid status %q", v)
+	}
+	return n, nil
+}
+
+// parseChain reads a chain ID query parameter; empty means any chain and is
+// returned as 0.
+func parseChain(v string) (int64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid chain %q", v)
+	}
+	return n, nil
+}
+
+// parseAddress validates an address.
+func parseAddress(v string) (common.Address, error) {
+	if !common.IsHexAddress(v) {
+		return common.Address{}, fmt.Errorf("invalid address %q", v)
+	}
+	return common.HexToAddress(v), nil
+}
+
+// parsePage reads the limit and cursor query parameters.
+func parsePage(r *http.Request) (limit int, after int64, err error) {
+	q := r.URL.Query()
+	limit = defaultLimit
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 {
+			return 0, 0, fmt.Errorf("invalid limit %q", v)
+		}
+		limit = min(limit, maxLimit)
+	}
+	if v := q.Get("cursor"); v != "" {
+		if after, err = decodeCursor(v); err != nil {
+			return 0, 0, err
+		}
+	}
+	return limit, after, nil
+}
+
+// A cursor is the opaque form of the id of the last deal on the previous
+// page.
+func encodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodeCursor(v string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(v)
+	var id int64
+	if err == nil {
+		id, err = strconv.ParseInt(string(raw), 10, 64)
+	}
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid cursor %q", v)
+	}
+	return id, nil
+}
+
+// setUser limits a deals query to the deals user takes part in, in role if
+// it is set.
+func setUser(q *store.DealQuery, user common.Address, role string) error {
+	if user == (common.Address{}) {
+		// The zero address takes part in no deal, and in a query it would
+		// match every deal.
+		return errors.New("invalid user: zero address")
+	}
+	switch role {
+	case "", "client", "freelancer", "arbiter":
+		q.User, q.Role = user, role
+		return nil
+	default:
+		return fmt.Errorf("invalid role %q", role)
+	}
+}
+
+// dealPage returns up to limit deals matching q, newest first, starting
+// after the deal with id after (or from the newest if it is 0).
+func (s *Server) dealPage(ctx context.Context, q store.DealQuery, limit int, after int64) (DealPage, error) {
+	q.Before, q.Limit = after, limit
+	deals, err := s.store.Deals(ctx, q)
+	if err != nil {
+		return DealPage{}, err
+	}
+	page := DealPage{Deals: make([]Deal, len(deals))}
+	for i, d := range deals {
+		page.Deals[i] = newDeal(d)
+	}
+	if len(deals) == limit {
+		page.NextCursor = encodeCursor(deals[len(deals)-1].ID)
+	}
+	return page, nil
+}
+
+// deal returns the deal at escrow on chainID, or nil if there is none. With
+// chainID 0 any chain matches, unless the address is used on several.
+func (s *Server) deal(ctx context.Context, chainID int64, escrow common.Address) (*Deal, error) {
+	if escrow == (common.Address{}) {
+		return nil, nil
+	}
+	deals, err := s.store.Deals(ctx, store.DealQuery{ChainID: chainID, Escrow: escrow, Limit: 2})
+	if err != nil {
+		return nil, err
+	}
+	switch len(deals) {
+	case 0:
+		return nil, nil
+	case 1:
+		d := newDeal(deals[0])
+		return &d, nil
+	default:
+		return nil, errAmbiguousDeal
+	}
+}
+
+// GET /deals?chain=&status=&token=&limit=&cursor=
+func (s *Server) listDeals(w http.ResponseWriter, r *http.Request) {
+	var q store.DealQuery
+	if err := parseCommonFilters(r, &q); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.queryDeals(w, r, q)
+}
+
+// GET /users/{address}/deals?role=&chain=&status=&token=&limit=&cursor=
+//
+// role is client, freelancer or arbiter; without it deals in any role are
+// returned.
+func (s *Server) listUserDeals(w http.ResponseWriter, r *http.Request) {
+	user, err := parseAddress(r.PathValue("address"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var q store.DealQuery
+	if err := setUser(&q, user, r.URL.Query().Get("role")); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := parseCommonFilters(r, &q); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.queryDeals(w, r, q)
+}
+
+func (s *Server) queryDeals(w http.ResponseWriter, r *http.Request, q store.DealQuery) {
+	limit, after, err := parsePage(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := s.dealPage(r.Context(), q, limit, after)
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// GET /deals/{address}?chain=
+//
+// chain is only needed if the address is in use on more than one chain.
+func (s *Server) getDeal(w http.ResponseWriter, r *http.Request) {
+	escrow, err := parseAddress(r.PathValue("address"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	chainID, err := parseChain(r.URL.Query().Get("chain"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	deal, err := s.deal(r.Context(), chainID, escrow)
+	if errors.Is(err, errAmbiguousDeal) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	if deal == nil {
+		writeError(w, http.StatusNotFound, "deal not found")
+		return
+	}
+	events, err := s.store.DealEvents(r.Context(), deal.ChainID, escrow)
+	if err != nil {
+		internalError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, DealDetail{Deal: *deal, Events: events})
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+// dealKey identifies a deal in a response.
+type dealKey struct {
+	ChainID int64  `json:"chainId"`
+	Address string `json:"address"`
+}
+
+type dealPageResponse struct {
+	Deals      []dealKey `json:"deals"`
+	NextCursor string    `json:"nextCursor"`
+}
+
+var (
+	bigDeal    = dealKey{bigChain, escrows[0].Hex()}
+	localDeals = []dealKey{{localChain, escrows[2].Hex()}, {localChain, escrows[1].Hex()}, {localChain, escrows[0].Hex()}}
+)
+
+func TestListDeals(t *testing.T) {
+	s, _ := newTestServer(t)
+	tests := []struct {
+		target string
+		want   []dealKey
+	}{
+		{"/deals", append(slices.Clone(localDeals), bigDeal)},
+		{"/deals?chain=31337", localDeals},
+		{"/deals?chain=1099511627776", []dealKey{bigDeal}},
+		{"/deals?chain=1", []dealKey{}},
+		{"/deals?status=funded", localDeals},
+		{"/deals?status=1", localDeals},
+		{"/deals?status=CREATED", []dealKey{bigDeal}},
+		{"/deals?token=" + token.Hex(), []dealKey{localDeals[0], localDeals[2]}},
+		{"/deals?chain=31337&status=funded&token=" + token.Hex(), []dealKey{localDeals[0], localDeals[2]}},
+	}
+	for _, tt := range tests {
+		var page dealPageResponse
+		if code := serve(t, s, http.MethodGet, tt.target, nil, &page); code != http.StatusOK {
+			t.Errorf("%s: status %d", tt.target, code)
+			continue
+		}
+		if !slices.Equal(page.Deals, tt.want) || page.NextCursor != "" {
+			t.Errorf("%s: got %+v, want %+v", tt.target, page, tt.want)
+		}
+	}
+}
+
+func TestListDealsBadRequest(t *testing.T) {
+	s, _ := newTestServer(t)
+	for _, target := range []string{
+		"/deals?chain=x",
+		"/deals?chain=0",
+		"/deals?chain=-1",
+		"/deals?status=5",
+		"/deals?status=-1",
+		"/deals?status=open",
+		"/deals?token=0x1",
+		"/deals?limit=0",
+		"/deals?limit=ten",
+		"/deals?cursor=!",
+		"/deals?cursor=" + encodeCursor(0),
+	} {
+		var body struct {
+			Error string `json:"error"`
+		}
+		if code := serve(t, s, http.MethodGet, target, nil, &body); code != http.StatusBadRequest || body.Error == "" {
+			t.Errorf("%s: status %d, body %+v; want 400 with an error", target, code, body)
+		}
+	}
+}
+
+// addDeals stores n more deals on localChain, from block 100 onwards.
+func addDeals(t *testing.T, st store.Store, n int) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := st.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	for i := range n {
+		escrow := common.BigToAddress(big.NewInt(int64(0x10000 + i)))
+		if _, err := tx.InsertDeal(ctx, testDeal(localChain, escrow, nil), logAt(uint64(100+i), 0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListDealsLimit(t *testing.T) {
+	s, st := newTestServer(t)
+	addDeals(t, st, 250)
+	tests := []struct {
+		target string
+		want   int
+	}{
+		{"/deals", defaultLimit},
+		{"/deals?limit=10", 10},
+		{"/deals?limit=200", maxLimit},
+		{"/deals?limit=1000", maxLimit},
+	}
+	for _, tt := range tests {
+		var page dealPageResponse
+		if code := serve(t, s, http.MethodGet, tt.target, nil, &page); code != http.StatusOK {
+			t.Errorf("%s: status %d", tt.target, code)
+			continue
+		}
+		if len(page.Deals) != tt.want || page.NextCursor == "" {
+			t.Errorf("%s: got %d deals and cursor %q, want %d and a cursor", tt.target, len(page.Deals), page.NextCursor, tt.want)
+		}
+	}
+}
+
+func TestGetDeal(t *testing.T) {
+	s, _ := newTestServer(t)
+	var detail struct {
+		dealKey
+		Status     int    `json:"status"`
+		StatusName string `json:"statusName"`
+		Events     []struct {
+			Name        string `json:"name"`
+			BlockNumber int64  `json:"blockNumber"`
+		} `json:"events"`
+	}
+	target := "/deals/" + escrows[1].Hex()
+	if code := serve(t, s, http.MethodGet, target, nil, &detail); code != http.StatusOK {
+		t.Fatalf("%s: status %d", target, code)
+	}
+	if detail.dealKey != localDeals[1] || detail.StatusName != "funded" || len(detail.Events) != 1 || detail.Events[0].BlockNumber != 12 {
+		t.Errorf("%s = %+v", target, detail)
+	}
+
+	target = "/deals/" + escrows[0].Hex() + "?chain=1099511627776"
+	if code := serve(t, s, http.MethodGet, target, nil, &detail); code != http.StatusOK || detail.dealKey != bigDeal || len(detail.Events) != 0 {
+		t.Errorf("%s: status %d, deal %+v", target, code, detail)
+	}
+
+	tests := []struct {
+		target string
+		code   int
+		error  string
+	}{
+		// escrows[0] is in use on two chains.
+		{"/deals/" + escrows[0].Hex(), http.StatusBadRequest, errAmbiguousDeal.Error()},
+		{"/deals/" + escrows[0].Hex() + "?chain=1", http.StatusNotFound, "deal not found"},
+		{"/deals/" + token.Hex(), http.StatusNotFound, "deal not found"},
+		{"/deals/" + common.Address{}.Hex(), http.StatusNotFound, "deal not found"},
+		{"/deals/0x1", http.StatusBadRequest, `invalid address "0x1"`},
+		{"/deals/" + escrows[1].Hex() + "?chain=x", http.StatusBadRequest, `invalid chain "x"`},
+	}
+	for _, tt := range tests {
+		var body struct {
+			Error string `json:"error"`
+		}
+		if code := serve(t, s, http.MethodGet, tt.target, nil, &body); code != tt.code || body.Error != tt.error {
+			t.Errorf("%s: status %d, error %q; want %d, %q", tt.target, code, body.Error, tt.code, tt.error)
+		}
+	}
+}
+
+func TestListUserDeals(t *testing.T) {
+	s, _ := newTestServer(t)
+	all := append(slices.Clone(localDeals), bigDeal)
+	tests := []struct {
+		target string
+		want   []dealKey
+	}{
+		{"/users/" + client.Hex() + "/deals", all},
+		{"/users/" + strings.ToLower(freelancer.Hex()) + "/deals?role=freelancer", all},
+		{"/users/" + arbiter.Hex() + "/deals?role=arbiter&chain=31337", localDeals},
+		{"/users/" + client.Hex() + "/deals?role=client&token=" + token.Hex(), []dealKey{localDeals[0], localDeals[2]}},
+		{"/users/" + client.Hex() + "/deals?role=freelancer", []dealKey{}},
+		{"/users/" + token.Hex() + "/deals", []dealKey{}},
+	}
+	for _, tt := range tests {
+		var page dealPageResponse
+		if code := serve(t, s, http.MethodGet, tt.target, nil, &page); code != http.StatusOK {
+			t.Errorf("%s: status %d", tt.target, code)
+			continue
+		}
+		if !slices.Equal(page.Deals, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.target, page.Deals, tt.want)
+		}
+	}
+
+	for _, target := range []string{
+		"/users/0x1/deals",
+		"/users/" + common.Address{}.Hex() + "/deals",
+		"/users/" + client.Hex() + "/deals?role=owner",
+		"/users/" + client.Hex() + "/deals?status=9",
+	} {
+		var body struct {
+			Error string `json:"error"`
+		}
+		if code := serve(t, s, http.MethodGet, target, nil, &body); code != http.StatusBadRequest || body.Error == "" {
+			t.Errorf("%s: status %d, body %+v; want 400 with an error", target, code, body)
+		}
+	}
+}
+
+// Following nextCursor pages through every deal once, newest first.
+func TestListUserDealsCursor(t *testing.T) {
+	s, st := newTestServer(t)
+	addDeals(t, st, 7)
+	var want dealPageResponse
+	serve(t, s, http.MethodGet, "/users/"+client.Hex()+"/deals", nil, &want)
+	if len(want.Deals) != 11 {
+		t.Fatalf("got %d deals, want 11", len(want.Deals))
+	}
+
+	var got []dealKey
+	target := "/users/" + client.Hex() + "/deals?limit=3"
+	for pages := 0; ; pages++ {
+		if pages == 5 {
+			t.Fatalf("still paging after %d pages", pages)
+		}
+		var page dealPageResponse
+		if code := serve(t, s, http.MethodGet, target, nil, &page); code != http.StatusOK {
+			t.Fatalf("%s: status %d", target, code)
+		}
+		got = append(got, page.Deals...)
+		if page.NextCursor == "" {
+			break
+		}
+		if _, err := base64.RawURLEncoding.DecodeString(page.NextCursor); err != nil {
+			t.Errorf("cursor %q isn't URL-safe base64: %v", page.NextCursor, err)
+		}
+		target = "/users/" + client.Hex() + "/deals?limit=3&cursor=" + page.NextCursor
+	}
+	if !slices.Equal(got, want.Deals) {
+		t.Errorf("paged through %+v, want %+v", got, want.Deals)
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	for _, id := range []int64{1, 42, 1 << 40} {
+		if got, err := decodeCursor(encodeCursor(id)); err != nil || got != id {
+			t.Errorf("cursor for %d decoded to %d, %v", id, got, err)
+		}
+	}
+}
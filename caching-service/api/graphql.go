@@ -0,0 +1,557 @@
+package api
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// maxQueryDepth stops clients from nesting deals → profile → deals → ...
+// without bound.
+const maxQueryDepth = 8
+
+func (s *Server) graphqlHandler() *relay.Handler {
+	return &relay.Handler{Schema: graphql.MustParseSchema(schema, &resolver{s}, graphql.MaxDepth(maxQueryDepth))}
+}
+
+type resolver struct {
+	s *Server
+}
+
+func (r *resolver) Deals(ctx context.Context, args struct {
+	First   *int32
+	After   *string
+	ChainID *Long
+	Status  *DealStatus
+	Token   *string
+	User    *string
+	Role    *string
+}) (*dealConnectionResolver, error) {
+	chainID, err := chainArg(args.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	q := store.DealQuery{ChainID: chainID}
+	if args.User != nil {
+		user, err := parseAddress(*args.User)
+		if err != nil {
+			return nil, err
+		}
+		var role string
+		if args.Role != nil {
+			role = *args.Role
+		}
+		if err := setUser(&q, user, role); err != nil {
+			return nil, err
+		}
+	} else if args.Role != nil {
+		return nil, errors.New("role requires user")
+	}
+	return r.s.dealConnection(ctx, q, args.First, args.After, args.Status, args.Token)
+}
+
+func (r *resolver) Deal(ctx context.Context, args struct {
+	Address string
+	ChainID *Long
+}) (*dealResolver, error) {
+	escrow, err := parseAddress(args.Address)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := chainArg(args.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	d, err := r.s.deal(ctx, chainID, escrow)
+	if err != nil || d == nil {
+		return nil, err
+	}
+	return newDealLoader(r.s, []Deal{*d}).resolvers()[0], nil
+}
+
+func (r *resolver) Arbiters(ctx context.Context, args struct {
+	ActiveOnly *bool
+	ChainID    *Long
+}) ([]*arbiterResolver, error) {
+	chainID, err := chainArg(args.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	arbiters, err := r.s.store.Arbiters(ctx, store.ArbiterQuery{
+		ChainID:    chainID,
+		ActiveOnly: args.ActiveOnly != nil && *args.ActiveOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*arbiterResolver, len(arbiters))
+	for i, a := range arbiters {
+		resolvers[i] = &arbiterResolver{r.s, a}
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) Arbiter(ctx context.Context, args struct {
+	Address string
+	ChainID *Long
+}) (*arbiterResolver, error) {
+	addr, err := parseAddress(args.Address)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := chainArg(args.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	return r.s.arbiter(ctx, chainID, addr)
+}
+
+func (r *resolver) Profile(ctx context.Context, args struct {
+	Address string
+	ChainID *Long
+}) (*profileResolver, error) {
+	addr, err := parseAddress(args.Address)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := chainArg(args.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	return r.s.profile(ctx, chainID, addr, false)
+}
+
+// chainArg returns the chainId argument, or 0 for any chain if it is
+// omitted.
+func chainArg(chainID *Long) (int64, error) {
+	if chainID == nil {
+		return 0, nil
+	}
+	if *chainID <= 0 {
+		return 0, fmt.Errorf("invalid chainId %d", *chainID)
+	}
+	return int64(*chainID), nil
+}
+
+// Long is a 64-bit integer, which chain IDs and block numbers need: Int is
+// only 32 bits. It is written as a decimal string so that JavaScript clients
+// don't lose precision, and read from a string or an Int.
+type Long int64
+
+func (Long) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+func (l *Long) UnmarshalGraphQL(input any) error {
+	switch v := input.(type) {
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid Long %q", v)
+		}
+		*l = Long(n)
+	case int32:
+		*l = Long(v)
+	case float64:
+		// Variables are decoded as JSON numbers.
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return fmt.Errorf("invalid Long %v", v)
+		}
+		*l = Long(v)
+	default:
+		return fmt.Errorf("invalid Long %v", input)
+	}
+	return nil
+}
+
+func (l Long) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, strconv.FormatInt(int64(l), 10)), nil
+}
+
+// DealStatus is a deal status filter, read like the REST status parameter:
+// a status name such as "funded", or its number as an Int or a string.
+type DealStatus int
+
+func (DealStatus) ImplementsGraphQLType(name string) bool { return name == "DealStatus" }
+
+func (s *DealStatus) UnmarshalGraphQL(input any) error {
+	var v string
+	switch in := input.(type) {
+	case string:
+		v = in
+	case int32:
+		v = strconv.Itoa(int(in))
+	case float64:
+		// Variables are decoded as JSON numbers.
+		v = strconv.FormatFloat(in, 'f', -1, 64)
+	default:
+		return fmt.Errorf("invalid status %v", input)
+	}
+	n, err := parseStatus(v)
+	if err != nil {
+		return err
+	}
+	*s = DealStatus(n)
+	return nil
+}
+
+func longPtr(n *int64) *Long {
+	if n == nil {
+		return nil
+	}
+	v := Long(*n)
+	return &v
+}
+
+// dealConnection applies the pagination and filter arguments shared by every
+// deals field on top of q.
+func (s *Server) dealConnection(ctx context.Context, q store.DealQuery, first *int32, after *string, status *DealStatus, token *string) (*dealConnectionResolver, error) {
+	limit := defaultLimit
+	if first != nil {
+		if *first <= 0 {
+			return nil, fmt.Errorf("invalid first %d", *first)
+		}
+		limit = min(int(*first), maxLimit)
+	}
+	var cursor int64
+	if after != nil {
+		var err error
+		if cursor, err = decodeCursor(*after); err != nil {
+			return nil, err
+		}
+	}
+	if status != nil {
+		n := int(*status)
+		q.Status = &n
+	}
+	if token != nil {
+		var err error
+		if q.Token, err = parseAddress(*token); err != nil {
+			return nil, err
+		}
+	}
+	page, err := s.dealPage(ctx, q, limit, cursor)
+	if err != nil {
+		return nil, err
+	}
+	return &dealConnectionResolver{page, newDealLoader(s, page.Deals)}, nil
+}
+
+type dealConnectionResolver struct {
+	page   DealPage
+	loader *dealLoader
+}
+
+func (c *dealConnectionResolver) Nodes() []*dealResolver {
+	return c.loader.resolvers()
+}
+
+func (c *dealConnectionResolver) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{c.page.NextCursor}
+}
+
+type pageInfoResolver struct {
+	next string
+}
+
+func (p *pageInfoResolver) EndCursor() *string {
+	if p.next == "" {
+		return nil
+	}
+	return &p.next
+}
+
+func (p *pageInfoResolver) HasNextPage() bool { return p.next != "" }
+
+// dealLoader loads the profiles, arbiters and events of a page of deals
+// the first time any deal asks for them, with one store query per chain on
+// the page rather than one per deal.
+type dealLoader struct {
+	s     *Server
+	deals []Deal
+
+	profilesOnce sync.Once
+	profiles     map[chainAddress]store.Profile
+	profilesErr  error
+
+	arbitersOnce sync.Once
+	arbiters     map[chainAddress]store.Arbiter
+	arbitersErr  error
+
+	eventsOnce sync.Once
+	events     map[chainAddress][]store.DealEvent
+	eventsErr  error
+}
+
+type chainAddress struct {
+	chainID int64
+	address common.Address
+}
+
+func newDealLoader(s *Server, deals []Deal) *dealLoader {
+	return &dealLoader{s: s, deals: deals}
+}
+
+func (l *dealLoader) resolvers() []*dealResolver {
+	resolvers := make([]*dealResolver, len(l.deals))
+	for i, d := range l.deals {
+		resolvers[i] = &dealResolver{l, d}
+	}
+	return resolvers
+}
+
+// byChain groups the non-zero addresses that addrs picks from each deal by
+// chain.
+func (l *dealLoader) byChain(addrs func(Deal) []string) map[int64][]common.Address {
+	chains := map[int64][]common.Address{}
+	seen := map[chainAddress]bool{}
+	for _, d := range l.deals {
+		for _, a := range addrs(d) {
+			key := chainAddress{d.ChainID, common.HexToAddress(a)}
+			if key.address == (common.Address{}) || seen[key] {
+				continue
+			}
+			seen[key] = true
+			chains[d.ChainID] = append(chains[d.ChainID], key.address)
+		}
+	}
+	return chains
+}
+
+func (l *dealLoader) profile(ctx context.Context, chainID int64, addr string) (*profileResolver, error) {
+	l.profilesOnce.Do(func() {
+		l.profiles = map[chainAddress]store.Profile{}
+		for chainID, addrs := range l.byChain(func(d Deal) []string { return []string{d.Client, d.Freelancer} }) {
+			found, err := l.s.store.Profiles(ctx, store.ProfileQuery{ChainID: chainID, Addresses: addrs, ActiveOnly: true})
+			if err != nil {
+				l.profilesErr = err
+				return
+			}
+			for _, p := range found {
+				l.profiles[chainAddress{chainID, common.HexToAddress(p.Address)}] = p
+			}
+		}
+	})
+	if l.profilesErr != nil {
+		return nil, l.profilesErr
+	}
+	p, ok := l.profiles[chainAddress{chainID, common.HexToAddress(addr)}]
+	if !ok {
+		return nil, nil
+	}
+	return &profileResolver{l.s, p}, nil
+}
+
+func (l *dealLoader) arbiter(ctx context.Context, chainID int64, addr string) (*arbiterResolver, error) {
+	l.arbitersOnce.Do(func() {
+		l.arbiters = map[chainAddress]store.Arbiter{}
+		for chainID, addrs := range l.byChain(func(d Deal) []string { return []string{d.Arbiter} }) {
+			found, err := l.s.store.Arbiters(ctx, store.ArbiterQuery{ChainID: chainID, Addresses: addrs})
+			if err != nil {
+				l.arbitersErr = err
+				return
+			}
+			for _, a := range found {
+				l.arbiters[chainAddress{chainID, common.HexToAddress(a.Address)}] = a
+			}
+		}
+	})
+	if l.arbitersErr != nil {
+		return nil, l.arbitersErr
+	}
+	a, ok := l.arbiters[chainAddress{chainID, common.HexToAddress(addr)}]
+	if !ok {
+		return nil, nil
+	}
+	return &arbiterResolver{l.s, a}, nil
+}
+
+func (l *dealLoader) dealEvents(ctx context.Context, chainID int64, escrow string) ([]store.DealEvent, error) {
+	l.eventsOnce.Do(func() {
+		l.events = map[chainAddress][]store.DealEvent{}
+		for chainID, escrows := range l.byChain(func(d Deal) []string { return []string{d.Address} }) {
+			found, err := l.s.store.DealEvents(ctx, chainID, escrows...)
+			if err != nil {
+				l.eventsErr = err
+				return
+			}
+			for _, e := range found {
+				key := chainAddress{chainID, common.HexToAddress(e.Escrow)}
+				l.events[key] = append(l.events[key], e)
+			}
+		}
+	})
+	if l.eventsErr != nil {
+		return nil, l.eventsErr
+	}
+	return l.events[chainAddress{chainID, common.HexToAddress(escrow)}], nil
+}
+
+type dealResolver struct {
+	l *dealLoader
+	d Deal
+}
+
+func (r *dealResolver) ChainID() Long               { return Long(r.d.ChainID) }
+func (r *dealResolver) Address() string             { return r.d.Address }
+func (r *dealResolver) Client() string              { return r.d.Client }
+func (r *dealResolver) Freelancer() string          { return r.d.Freelancer }
+func (r *dealResolver) Arbiter() string             { return r.d.Arbiter }
+func (r *dealResolver) TotalAmount() string         { return r.d.TotalAmount }
+func (r *dealResolver) Token() *string              { return r.d.Token }
+func (r *dealResolver) ProjectDescription() *string { return r.d.ProjectDescription }
+func (r *dealResolver) Status() int32               { return int32(r.d.Status) }
+func (r *dealResolver) StatusName() string          { return r.d.StatusName }
+func (r *dealResolver) ConfirmationStatus() string  { return r.d.ConfirmationStatus }
+func (r *dealResolver) BlockNumber() *Long          { return longPtr(r.d.BlockNumber) }
+func (r *dealResolver) TxHash() *string             { return r.d.TxHash }
+func (r *dealResolver) CreatedAt() string           { return r.d.CreatedAt.Format(time.RFC3339) }
+
+func (r *dealResolver) ClientProfile(ctx context.Context) (*profileResolver, error) {
+	return r.l.profile(ctx, r.d.ChainID, r.d.Client)
+}
+
+func (r *dealResolver) FreelancerProfile(ctx context.Context) (*profileResolver, error) {
+	return r.l.profile(ctx, r.d.ChainID, r.d.Freelancer)
+}
+
+func (r *dealResolver) ArbiterInfo(ctx context.Context) (*arbiterResolver, error) {
+	return r.l.arbiter(ctx, r.d.ChainID, r.d.Arbiter)
+}
+
+func (r *dealResolver) Events(ctx context.Context) ([]*dealEventResolver, error) {
+	events, err := r.l.dealEvents(ctx, r.d.ChainID, r.d.Address)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*dealEventResolver, len(events))
+	for i, e := range events {
+		resolvers[i] = &dealEventResolver{e}
+	}
+	return resolvers, nil
+}
+
+type dealEventResolver struct {
+	e store.DealEvent
+}
+
+func (r *dealEventResolver) Name() string            { return r.e.Name }
+func (r *dealEventResolver) Actor() *string          { return r.e.Actor }
+func (r *dealEventResolver) Amount() *string         { return r.e.Amount }
+func (r *dealEventResolver) WorkSubmission() *string { return r.e.WorkSubmission }
+func (r *dealEventResolver) Status() int32           { return int32(r.e.Status) }
+func (r *dealEventResolver) BlockNumber() Long       { return Long(r.e.BlockNumber) }
+func (r *dealEventResolver) TxHash() string          { return r.e.TxHash }
+func (r *dealEventResolver) LogIndex() int32         { return int32(r.e.LogIndex) }
+
+// arbiter returns the registry entry for addr on chainID, or nil if it was
+// never added. With chainID 0 any chain matches, unless addr is registered
+// on several.
+func (s *Server) arbiter(ctx context.Context, chainID int64, addr common.Address) (*arbiterResolver, error) {
+	// A zero address would match every arbiter; it is what deals stored
+	// without their escrow's details have.
+	if addr == (common.Address{}) {
+		return nil, nil
+	}
+	found, err := s.store.Arbiters(ctx, store.ArbiterQuery{ChainID: chainID, Address: addr, Limit: 2})
+	if err != nil {
+		return nil, err
+	}
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &arbiterResolver{s, found[0]}, nil
+	default:
+		return nil, errors.New("arbiter is registered on several chains; pass chainId")
+	}
+}
+
+type arbiterResolver struct {
+	s *Server
+	a store.Arbiter
+}
+
+func (r *arbiterResolver) ChainID() Long        { return Long(r.a.ChainID) }
+func (r *arbiterResolver) Address() string      { return r.a.Address }
+func (r *arbiterResolver) Name() string         { return r.a.Name }
+func (r *arbiterResolver) ProfileHash() *string { return r.a.ProfileHash }
+func (r *arbiterResolver) IsActive() bool       { return r.a.IsActive }
+func (r *arbiterResolver) AddedBlock() Long     { return Long(r.a.AddedBlock) }
+func (r *arbiterResolver) RemovedBlock() *Long  { return longPtr(r.a.RemovedBlock) }
+
+func (r *arbiterResolver) Profile(ctx context.Context) (*profileResolver, error) {
+	return r.s.profile(ctx, r.a.ChainID, common.HexToAddress(r.a.Address), true)
+}
+
+func (r *arbiterResolver) Deals(ctx context.Context, args struct {
+	First  *int32
+	After  *string
+	Status *DealStatus
+	Token  *string
+}) (*dealConnectionResolver, error) {
+	q := store.DealQuery{ChainID: r.a.ChainID, User: common.HexToAddress(r.a.Address), Role: "arbiter"}
+	return r.s.dealConnection(ctx, q, args.First, args.After, args.Status, args.Token)
+}
+
+// profile returns the profile for addr on chainID, or nil if there is none
+// (or, with activeOnly, if it was deleted). With chainID 0 any chain
+// matches, unless addr has a profile on several.
+func (s *Server) profile(ctx context.Context, chainID int64, addr common.Address, activeOnly bool) (*profileResolver, error) {
+	if addr == (common.Address{}) {
+		return nil, nil
+	}
+	found, err := s.store.Profiles(ctx, store.ProfileQuery{ChainID: chainID, Address: addr, ActiveOnly: activeOnly, Limit: 2})
+	if err != nil {
+		return nil, err
+	}
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &profileResolver{s, found[0]}, nil
+	default:
+		return nil, errors.New("address has a profile on several chains; pass chainId")
+	}
+}
+
+type profileResolver struct {
+	s *Server
+	p store.Profile
+}
+
+func (r *profileResolver) ChainID() Long            { return Long(r.p.ChainID) }
+func (r *profileResolver) Address() string          { return r.p.Address }
+func (r *profileResolver) Username() string         { return r.p.Username }
+func (r *profileResolver) Bio() string              { return r.p.Bio }
+func (r *profileResolver) ProfileImageHash() string { return r.p.ProfileImageHash }
+func (r *profileResolver) IsActive() bool           { return r.p.IsActive }
+
+func (r *profileResolver) Deals(ctx context.Context, args struct {
+	First  *int32
+	After  *string
+	Status *DealStatus
+	Token  *string
+	Role   *string
+}) (*dealConnectionResolver, error) {
+	var role string
+	if args.Role != nil {
+		role = *args.Role
+	}
+	q := store.DealQuery{ChainID: r.p.ChainID}
+	if err := setUser(&q, common.HexToAddress(r.p.Address), role); err != nil {
+		return nil, err
+	}
+	return r.s.dealConnection(ctx, q, args.First, args.After, args.Status, args.Token)
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// query runs q and decodes its data into out, failing on errors.
+func query(t *testing.T, s *Server, q string, vars map[string]any, out any) {
+	t.Helper()
+	resp := queryErrors(t, s, q, vars)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("%s: %+v", q, resp.Errors)
+	}
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func queryErrors(t *testing.T, s *Server, q string, vars map[string]any) graphqlResponse {
+	t.Helper()
+	var resp graphqlResponse
+	body := map[string]any{"query": q, "variables": vars}
+	if code := serve(t, s, http.MethodPost, "/graphql", body, &resp); code != http.StatusOK {
+		t.Fatalf("%s: status %d", q, code)
+	}
+	return resp
+}
+
+func TestGraphQLLong(t *testing.T) {
+	s, _ := newTestServer(t)
+	var out struct {
+		Deals struct {
+			Nodes []struct {
+				ChainID     string `json:"chainId"`
+				BlockNumber string `json:"blockNumber"`
+			} `json:"nodes"`
+		} `json:"deals"`
+	}
+	queries := []struct {
+		query string
+		vars  map[string]any
+	}{
+		{query: `{ deals(chainId: "1099511627776") { nodes { chainId blockNumber } } }`},
+		{query: `query($chain: Long) { deals(chainId: $chain) { nodes { chainId blockNumber } } }`, vars: map[string]any{"chain": bigChain}},
+		{query: `query($chain: Long) { deals(chainId: $chain) { nodes { chainId blockNumber } } }`, vars: map[string]any{"chain": "1099511627776"}},
+	}
+	for _, q := range queries {
+		query(t, s, q.query, q.vars, &out)
+		if nodes := out.Deals.Nodes; len(nodes) != 1 || nodes[0].ChainID != "1099511627776" || nodes[0].BlockNumber != "8589934592" {
+			t.Errorf("%s: nodes = %+v", q.query, nodes)
+		}
+	}
+
+	query(t, s, `{ deals(chainId: 31337) { nodes { chainId } } }`, nil, &out)
+	if len(out.Deals.Nodes) != 3 {
+		t.Errorf("got %d deals on chain 31337, want 3", len(out.Deals.Nodes))
+	}
+
+	for _, chain := range []string{`"x"`, `0`, `"-1"`} {
+		resp := queryErrors(t, s, `{ deals(chainId: `+chain+`) { nodes { chainId } } }`, nil)
+		if len(resp.Errors) == 0 {
+			t.Errorf("chainId %s was accepted", chain)
+		}
+	}
+}
+
+func TestGraphQLStatus(t *testing.T) {
+	s, _ := newTestServer(t)
+	var out struct {
+		Deals struct {
+			Nodes []struct {
+				Status     int    `json:"status"`
+				StatusName string `json:"statusName"`
+			} `json:"nodes"`
+		} `json:"deals"`
+	}
+	// Statuses are read like the REST status parameter.
+	queries := []struct {
+		query string
+		vars  map[string]any
+	}{
+		{query: `{ deals(chainId: 31337, status: 1) { nodes { status statusName } } }`},
+		{query: `{ deals(chainId: 31337, status: "funded") { nodes { status statusName } } }`},
+		{query: `{ deals(chainId: 31337, status: "1") { nodes { status statusName } } }`},
+		{query: `query($status: DealStatus) { deals(chainId: 31337, status: $status) { nodes { status statusName } } }`, vars: map[string]any{"status": "FUNDED"}},
+		{query: `query($status: DealStatus) { deals(chainId: 31337, status: $status) { nodes { status statusName } } }`, vars: map[string]any{"status": 1}},
+	}
+	for _, q := range queries {
+		query(t, s, q.query, q.vars, &out)
+		if nodes := out.Deals.Nodes; len(nodes) != 3 || nodes[0].Status != 1 || nodes[0].StatusName != "funded" {
+			t.Errorf("%s %v: funded deals = %+v", q.query, q.vars, nodes)
+		}
+	}
+	query(t, s, `{ deals(status: "created") { nodes { status } } }`, nil, &out)
+	if nodes := out.Deals.Nodes; len(nodes) != 1 {
+		t.Errorf("created deals = %+v", nodes)
+	}
+
+	for _, status := range []string{`-1`, `5`, `"open"`, `1.5`} {
+		resp := queryErrors(t, s, `{ deals(status: `+status+`) { nodes { status } } }`, nil)
+		if len(resp.Errors) == 0 {
+			t.Errorf("status %s was accepted", status)
+		}
+	}
+	resp := queryErrors(t, s, `query($status: DealStatus) { deals(status: $status) { nodes { status } } }`, map[string]any{"status": 7})
+	if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, `invalid status "7"`) {
+		t.Errorf("status 7: errors %+v", resp.Errors)
+	}
+}
+
+// The nested fields of a page of deals are read with one store query each,
+// however many deals the page has.
+func TestGraphQLDealFieldsBatched(t *testing.T) {
+	s, st := newTestServer(t)
+	var out struct {
+		Deals struct {
+			Nodes []struct {
+				Address       string `json:"address"`
+				ClientProfile *struct {
+					Username string `json:"username"`
+				} `json:"clientProfile"`
+				FreelancerProfile *struct{} `json:"freelancerProfile"`
+				ArbiterInfo       *struct {
+					Name       string `json:"name"`
+					AddedBlock string `json:"addedBlock"`
+				} `json:"arbiterInfo"`
+				Events []struct {
+					Name        string `json:"name"`
+					BlockNumber string `json:"blockNumber"`
+				} `json:"events"`
+			} `json:"nodes"`
+		} `json:"deals"`
+	}
+	query(t, s, `{ deals(chainId: 31337) { nodes {
+		address
+		clientProfile { username }
+		freelancerProfile { username }
+		arbiterInfo { name addedBlock }
+		events { name blockNumber }
+	} } }`, nil, &out)
+
+	nodes := out.Deals.Nodes
+	if len(nodes) != 3 {
+		t.Fatalf("got %d deals, want 3", len(nodes))
+	}
+	for i, d := range nodes {
+		// Newest first.
+		block := 13 - i
+		switch {
+		case d.Address != escrows[2-i].Hex(),
+			d.ClientProfile == nil || d.ClientProfile.Username != "alice",
+			d.FreelancerProfile != nil,
+			d.ArbiterInfo == nil || d.ArbiterInfo.Name != "Arbiter" || d.ArbiterInfo.AddedBlock != "5",
+			len(d.Events) != 1 || d.Events[0].Name != "AgreementFunded" || d.Events[0].BlockNumber != strconv.Itoa(block):
+			t.Errorf("deal %d = %+v", i, d)
+		}
+	}
+	if p, a, e := st.profiles.Load(), st.arbiters.Load(), st.dealEvents.Load(); p != 1 || a != 1 || e != 1 {
+		t.Errorf("read profiles %d times, arbiters %d times and events %d times, want once each", p, a, e)
+	}
+}
+
+// A deal looked up on its own still resolves its nested fields.
+func TestGraphQLDeal(t *testing.T) {
+	s, _ := newTestServer(t)
+	var out struct {
+		Deal *struct {
+			ChainID     string `json:"chainId"`
+			ArbiterInfo *struct {
+				Address string `json:"address"`
+			} `json:"arbiterInfo"`
+			Events []struct{} `json:"events"`
+		} `json:"deal"`
+	}
+	query(t, s, `{ deal(address: "`+escrows[1].Hex()+`") { chainId arbiterInfo { address } events { name } } }`, nil, &out)
+	if d := out.Deal; d == nil || d.ChainID != "31337" || d.ArbiterInfo == nil || d.ArbiterInfo.Address != arbiter.Hex() || len(d.Events) != 1 {
+		t.Errorf("deal = %+v", d)
+	}
+
+	resp := queryErrors(t, s, `{ deal(address: "`+escrows[0].Hex()+`") { chainId } }`, nil)
+	if len(resp.Errors) == 0 || resp.Errors[0].Message != errAmbiguousDeal.Error() {
+		t.Errorf("ambiguous deal: errors %+v", resp.Errors)
+	}
+	query(t, s, `{ deal(address: "`+escrows[0].Hex()+`", chainId: "1099511627776") { chainId } }`, nil, &out)
+	if d := out.Deal; d == nil || d.ChainID != "1099511627776" {
+		t.Errorf("deal on bigChain = %+v", d)
+	}
+}
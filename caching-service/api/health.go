@@ -0,0 +1,14 @@
+package api
+
+import "net/http"
+
+// health reports each indexer's connection state. It answers 503 unless
+// every indexer is following the chain head, so load balancers and
+// orchestrators can tell a service that is catching up or reconnecting.
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	status := http.StatusOK
+	if !s.indexers.Live() {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, map[string]any{"indexers": s.indexers.States()})
+}
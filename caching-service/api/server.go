@@ -0,0 +1,64 @@
+// Package api serves the caching service's store over HTTP so the frontend
+// doesn't have to read the chain directly.
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/indexer"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+// Server is the HTTP handler for the caching service API.
+type Server struct {
+	store    store.Store
+	hub      *Hub
+	indexers *indexer.Health
+	mux      *http.ServeMux
+}
+
+// New creates a Server reading from st, streaming updates from hub and
+// reporting the indexers' state from health.
+func New(st store.Store, hub *Hub, health *indexer.Health) *Server {
+	s := &Server{store: st, hub: hub, indexers: health, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /deals", s.listDeals)
+	s.mux.HandleFunc("GET /deals/{address}", s.getDeal)
+	s.mux.HandleFunc("GET /users/{address}/deals", s.listUserDeals)
+	s.mux.HandleFunc("GET /stream", s.stream)
+	s.mux.Handle("POST /graphql", s.graphqlHandler())
+	s.mux.HandleFunc("GET /health", s.health)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The frontend calls the API from the browser on a different origin.
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if r.Method == http.MethodOptions {
+		// CORS preflight, sent before JSON POSTs to /graphql.
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+// internalError logs err and hides it from the client.
+func internalError(w http.ResponseWriter, r *http.Request, err error) {
+	log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
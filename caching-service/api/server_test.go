@@ -0,0 +1,150 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/indexer"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+const (
+	localChain = 31337
+	// bigChain doesn't fit in 32 bits.
+	bigChain = 1 << 40
+)
+
+var (
+	client     = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	freelancer = common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+	arbiter    = common.HexToAddress("0x90F79bf6EB2c4f870365E785982E1f101E93b906")
+	token      = common.HexToAddress("0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0")
+	// escrows are the local chain's deals, oldest first. The first is also
+	// in use on bigChain.
+	escrows = []common.Address{
+		common.HexToAddress("0x94099942864EA81cCF197E9D71ac53310b1468D8"),
+		common.HexToAddress("0x8464135c8F25Da09e49BC8782676a84730C318bC"),
+		common.HexToAddress("0x71C95911E9a5D330f4D621842EC243EE1343292e"),
+	}
+)
+
+// countingStore counts the reads the per-deal GraphQL fields make.
+type countingStore struct {
+	store.Store
+	profiles, arbiters, dealEvents atomic.Int32
+}
+
+func (s *countingStore) Profiles(ctx context.Context, q store.ProfileQuery) ([]store.Profile, error) {
+	s.profiles.Add(1)
+	return s.Store.Profiles(ctx, q)
+}
+
+func (s *countingStore) Arbiters(ctx context.Context, q store.ArbiterQuery) ([]store.Arbiter, error) {
+	s.arbiters.Add(1)
+	return s.Store.Arbiters(ctx, q)
+}
+
+func (s *countingStore) DealEvents(ctx context.Context, chainID int64, escrows ...common.Address) ([]store.DealEvent, error) {
+	s.dealEvents.Add(1)
+	return s.Store.DealEvents(ctx, chainID, escrows...)
+}
+
+// newTestServer returns a Server over a memory store holding:
+//   - on localChain, the deals at escrows from blocks 10 to 12, each funded
+//     in the next block and with the first and last in the token; alice's
+//     profile for the client; and the arbiter, added at block 5.
+//   - on bigChain, a deal at escrows[0] from block 1<<33.
+func newTestServer(t *testing.T) (*Server, *countingStore) {
+	t.Helper()
+	ctx := context.Background()
+	st := &countingStore{Store: store.NewMemory()}
+	tx, err := st.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	name := "Arbiter"
+	writes := []func() (bool, error){
+		func() (bool, error) {
+			return tx.ApplyRegistryEvent(ctx, store.RegistryEvent{ChainID: localChain, Name: "ArbiterAdded", Address: arbiter, ArbiterName: &name}, logAt(5, 0))
+		},
+		func() (bool, error) {
+			return tx.ApplyProfileEvent(ctx, store.ProfileEvent{ChainID: localChain, Name: "ProfileUpdated", Address: client, Username: "alice"}, logAt(5, 1))
+		},
+		func() (bool, error) {
+			return tx.InsertDeal(ctx, testDeal(bigChain, escrows[0], nil), logAt(1<<33, 0))
+		},
+	}
+	for i, escrow := range escrows {
+		block := uint64(10 + i)
+		var dealToken *common.Address
+		if i != 1 {
+			dealToken = &token
+		}
+		writes = append(writes,
+			func() (bool, error) {
+				return tx.InsertDeal(ctx, testDeal(localChain, escrow, dealToken), logAt(block, 0))
+			},
+			func() (bool, error) {
+				funded := store.NewDealEvent{ChainID: localChain, Escrow: escrow, Name: "AgreementFunded", Amount: big.NewInt(100), Status: 1}
+				return tx.ApplyDealEvent(ctx, funded, logAt(block+1, 1))
+			},
+		)
+	}
+	for _, write := range writes {
+		if _, err := write(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	return New(st, NewHub(), indexer.NewHealth()), st
+}
+
+func testDeal(chainID int64, escrow common.Address, token *common.Address) store.NewDeal {
+	return store.NewDeal{
+		ChainID:            chainID,
+		Escrow:             escrow,
+		Client:             client,
+		Freelancer:         freelancer,
+		Arbiter:            arbiter,
+		TotalAmount:        big.NewInt(100),
+		Token:              token,
+		ConfirmationStatus: store.StatusPending,
+	}
+}
+
+func logAt(block uint64, index uint) types.Log {
+	return types.Log{
+		BlockNumber: block,
+		Index:       index,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(block)),
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(block*1000 + uint64(index))),
+	}
+}
+
+// serve sends a request with body, if not nil, as JSON and decodes the
+// response into out.
+func serve(t *testing.T, s *Server, method, target string, body any, out any) int {
+	t.Helper()
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(method, target, &reqBody))
+	if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+		t.Fatalf("%s %s: decoding %q: %v", method, target, rec.Body, err)
+	}
+	return rec.Code
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+// heartbeatInterval keeps idle streams from being closed by proxies.
+const heartbeatInterval = 15 * time.Second
+
+// Hub fans deal updates out to the connected stream subscribers.
+type Hub struct {
+	mu   sync.Mutex
+	subs map[*subscriber]struct{}
+	// heartbeat is how often idle streams are sent a comment.
+	heartbeat time.Duration
+}
+
+type subscriber struct {
+	chains  map[int64]bool
+	users   map[string]bool
+	escrows map[string]bool
+	updates chan store.DealUpdate
+}
+
+// wants reports whether the subscriber asked for u. Without chains updates
+// from every chain match, and without users or escrows every deal does.
+func (s *subscriber) wants(u store.DealUpdate) bool {
+	if len(s.chains) > 0 && !s.chains[u.ChainID] {
+		return false
+	}
+	if len(s.users) == 0 && len(s.escrows) == 0 {
+		return true
+	}
+	return s.escrows[u.Escrow] || s.users[u.Client] || s.users[u.Freelancer] || s.users[u.Arbiter]
+}
+
+// NewHub creates an empty Hub.
+func NewHub() *Hub {
+	return &Hub{subs: make(map[*subscriber]struct{}), heartbeat: heartbeatInterval}
+}
+
+// Run forwards the deal updates committed to st until ctx is cancelled.
+func (h *Hub) Run(ctx context.Context, st store.Store) error {
+	return st.ListenDealUpdates(ctx, h.publish)
+}
+
+func (h *Hub) publish(u store.DealUpdate) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs {
+		if !sub.wants(u) {
+			continue
+		}
+		select {
+		case sub.updates <- u:
+		default:
+			// The client isn't keeping up; drop it rather than stall
+			// everyone else. It will reconnect and refetch.
+			delete(h.subs, sub)
+			close(sub.updates)
+		}
+	}
+}
+
+func (h *Hub) subscribe(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subs[sub] = struct{}{}
+}
+
+func (h *Hub) unsubscribe(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subs[sub]; ok {
+		delete(h.subs, sub)
+		close(sub.updates)
+	}
+}
+
+// GET /stream?chain=&user=&escrow=
+//
+// Streams deal updates as server-sent events. chain, user and escrow may
+// each be repeated; without user or escrow every deal's updates are sent.
+func (s *Server) stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+	sub := &subscriber{
+		chains:  make(map[int64]bool),
+		users:   make(map[string]bool),
+		escrows: make(map[string]bool),
+		updates: make(chan store.DealUpdate, 64),
+	}
+	q := r.URL.Query()
+	for _, v := range q["chain"] {
+		chainID, err := parseChain(v)
+		if err != nil || chainID == 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid chain %q", v))
+			return
+		}
+		sub.chains[chainID] = true
+	}
+	for _, v := range q["user"] {
+		addr, err := parseAddress(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sub.users[addr.Hex()] = true
+	}
+	for _, v := range q["escrow"] {
+		addr, err := parseAddress(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sub.escrows[addr.Hex()] = true
+	}
+
+	s.hub.subscribe(sub)
+	defer s.hub.unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(s.hub.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		case u, ok := <-sub.updates:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(u)
+			if err != nil {
+				log.Printf("Failed to encode deal update: %v", err)
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", u.Event, data)
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+// sseStream reads the events of a /stream response.
+type sseStream struct {
+	t    *testing.T
+	body *bufio.Reader
+}
+
+// openStream connects to /stream with query and waits until it is
+// subscribed.
+func openStream(t *testing.T, s *Server, query string) *sseStream {
+	t.Helper()
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/stream?"+query, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("/stream?%s: status %d, content type %q", query, resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	stream := &sseStream{t: t, body: bufio.NewReader(resp.Body)}
+	if got := stream.next(); got != ": connected" {
+		t.Fatalf("first message %q, want the connected comment", got)
+	}
+	return stream
+}
+
+// next returns the next message, with its lines joined by "|".
+func (s *sseStream) next() string {
+	s.t.Helper()
+	var lines []string
+	for {
+		line, err := s.body.ReadString('\n')
+		if err != nil {
+			s.t.Fatalf("reading stream: %v (read %q)", err, lines)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return strings.Join(lines, "|")
+		}
+		lines = append(lines, line)
+	}
+}
+
+func update(event string, chainID int64, escrow string) store.DealUpdate {
+	return store.DealUpdate{Event: event, ChainID: chainID, Escrow: escrow, Client: client.Hex(), Freelancer: freelancer.Hex(), Arbiter: arbiter.Hex()}
+}
+
+func TestStreamFiltersUpdates(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.hub.heartbeat = time.Hour
+	byEscrow := openStream(t, s, "escrow="+escrows[1].Hex()+"&chain=31337")
+	byUser := openStream(t, s, "user="+strings.ToLower(client.Hex()))
+	other := update("AgreementFunded", localChain, escrows[0].Hex())
+	other.Client = token.Hex()
+
+	s.hub.publish(update("AgreementFunded", bigChain, escrows[1].Hex()))
+	s.hub.publish(other)
+	s.hub.publish(update("WorkSubmitted", localChain, escrows[1].Hex()))
+
+	// byEscrow skips the other chain's update and the other deal's.
+	msg := byEscrow.next()
+	event, data, _ := strings.Cut(msg, "|")
+	var u store.DealUpdate
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(data, "data: ")), &u); err != nil {
+		t.Fatalf("message %q: %v", msg, err)
+	}
+	if event != "event: WorkSubmitted" || u.Escrow != escrows[1].Hex() || u.ChainID != localChain {
+		t.Errorf("escrow subscriber got %q", msg)
+	}
+
+	// byUser gets every update the client takes part in, on any chain.
+	for _, want := range []string{"event: AgreementFunded", "event: WorkSubmitted"} {
+		if msg := byUser.next(); !strings.HasPrefix(msg, want+"|") {
+			t.Errorf("user subscriber got %q, want %s", msg, want)
+		}
+	}
+}
+
+// listenStore hands the function ListenDealUpdates is called with to the
+// test.
+type listenStore struct {
+	store.Store
+	listeners chan func(store.DealUpdate)
+}
+
+func (s listenStore) ListenDealUpdates(ctx context.Context, fn func(store.DealUpdate)) error {
+	s.listeners <- fn
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestHubRun(t *testing.T) {
+	s, st := newTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ls := listenStore{Store: st, listeners: make(chan func(store.DealUpdate))}
+	go s.hub.Run(ctx, ls)
+	stream := openStream(t, s, "")
+
+	notify := <-ls.listeners
+	notify(update("WorkApproved", localChain, escrows[2].Hex()))
+	if msg := stream.next(); !strings.HasPrefix(msg, "event: WorkApproved|") || !strings.Contains(msg, escrows[2].Hex()) {
+		t.Errorf("got %q, want the store's update", msg)
+	}
+}
+
+func TestStreamHeartbeat(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.hub.heartbeat = 10 * time.Millisecond
+	stream := openStream(t, s, "")
+	for range 2 {
+		if msg := stream.next(); msg != ": heartbeat" {
+			t.Errorf("got %q, want a heartbeat", msg)
+		}
+	}
+}
+
+func TestStreamBadRequest(t *testing.T) {
+	s, _ := newTestServer(t)
+	for _, target := range []string{"/stream?chain=0", "/stream?user=0x1", "/stream?escrow=bob"} {
+		var body struct {
+			Error string `json:"error"`
+		}
+		if code := serve(t, s, http.MethodGet, target, nil, &body); code != http.StatusBadRequest || body.Error == "" {
+			t.Errorf("%s: status %d, body %+v; want 400 with an error", target, code, body)
+		}
+	}
+}
+
+// A subscriber whose buffer is full is dropped, and unsubscribing it
+// afterwards is harmless.
+func TestHubDropsSlowSubscriber(t *testing.T) {
+	h := NewHub()
+	slow := &subscriber{updates: make(chan store.DealUpdate, 1)}
+	fast := &subscriber{updates: make(chan store.DealUpdate, 4)}
+	h.subscribe(slow)
+	h.subscribe(fast)
+
+	h.publish(update("AgreementFunded", localChain, escrows[0].Hex()))
+	h.publish(update("WorkSubmitted", localChain, escrows[0].Hex()))
+
+	if u, ok := <-slow.updates; !ok || u.Event != "AgreementFunded" {
+		t.Errorf("slow subscriber got %+v, %v; want the first update", u, ok)
+	}
+	if _, ok := <-slow.updates; ok {
+		t.Error("slow subscriber's updates weren't closed")
+	}
+	if len(fast.updates) != 2 {
+		t.Errorf("fast subscriber has %d updates, want 2", len(fast.updates))
+	}
+	h.mu.Lock()
+	_, subscribed := h.subs[slow]
+	h.mu.Unlock()
+	if subscribed {
+		t.Error("slow subscriber is still subscribed")
+	}
+	h.unsubscribe(slow)
+	h.unsubscribe(fast)
+}
@@ -0,0 +1,15 @@
+// Code generated by cmd/bindgen - DO NOT EDIT.
+
+package arbiterregistry
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/bindcheck"
+)
+
+// Artifact describes the Hardhat artifact this binding was generated from.
+var Artifact = bindcheck.Artifact{
+	Contract:             "contracts/ArbiterRegistry.sol:ArbiterRegistry",
+	DeployedBytecodeHash: common.HexToHash("0xa73fb957b4ed45c9276e2fd3fd0a573fd8530c55f7cabdbc11c03d530a24f323"),
+	ImmutableRanges:      []bindcheck.Range{},
+}
@@ -0,0 +1,126 @@
+// Package bindcheck verifies that a deployed contract matches the Go binding
+// used to talk to it.
+package bindcheck
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EVM opcodes bounding the PUSH1..PUSH32 family.
+const (
+	opPush1  = 0x60
+	opPush32 = 0x7f
+)
+
+// Range is a byte range of runtime bytecode.
+type Range struct {
+	Start  int
+	Length int
+}
+
+// Artifact records the Hardhat build artifact a binding was generated from.
+// Each generated binding package exports one as its Artifact variable.
+type Artifact struct {
+	// Contract is the fully qualified name, e.g. contracts/Foo.sol:Foo.
+	Contract string
+	// DeployedBytecodeHash is the keccak256 of the runtime bytecode with the
+	// ImmutableRanges zeroed, or the zero hash if the artifact had no bytecode.
+	DeployedBytecodeHash common.Hash
+	// ImmutableRanges are filled in by the constructor at deploy time and so
+	// differ between deployments of the same code.
+	ImmutableRanges []Range
+}
+
+// CodeHash returns the keccak256 of code with the given ranges zeroed.
+func CodeHash(code []byte, immutables []Range) common.Hash {
+	masked := common.CopyBytes(code)
+	for _, r := range immutables {
+		if r.Start+r.Length <= len(masked) {
+			clear(masked[r.Start : r.Start+r.Length])
+		}
+	}
+	return crypto.Keccak256Hash(masked)
+}
+
+// Verify checks the code deployed at addr against a binding's ABI and
+// artifact. Every function selector and event topic in the ABI must appear
+// in the runtime bytecode, otherwise calls built by the binding would revert
+// or its filters would never match; that is reported as an error. A differing
+// bytecode hash on its own only means the contract was compiled differently,
+// so it is logged as a warning.
+func Verify(ctx context.Context, caller bind.ContractCaller, addr common.Address, meta *bind.MetaData, artifact Artifact) error {
+	code, err := caller.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return fmt.Errorf("get code at %s: %w", addr.Hex(), err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("no contract deployed at %s", addr.Hex())
+	}
+	contractAbi, err := abi.JSON(strings.NewReader(meta.ABI))
+	if err != nil {
+		return fmt.Errorf("parse ABI: %w", err)
+	}
+
+	selectors, topics := pushedConstants(code)
+	var missing []string
+	for _, method := range contractAbi.Methods {
+		if _, ok := selectors[[4]byte(method.ID)]; !ok {
+			missing = append(missing, "function "+method.Sig)
+		}
+	}
+	for _, event := range contractAbi.Events {
+		if event.Anonymous {
+			continue
+		}
+		if _, ok := topics[event.ID]; !ok {
+			missing = append(missing, "event "+event.Sig)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("contract at %s does not match the %s binding; missing %s",
+			addr.Hex(), artifact.Contract, strings.Join(missing, ", "))
+	}
+
+	if artifact.DeployedBytecodeHash != (common.Hash{}) {
+		if got := CodeHash(code, artifact.ImmutableRanges); got != artifact.DeployedBytecodeHash {
+			log.Printf("Warning: bytecode at %s differs from the %s artifact the binding was generated from (ABI still matches)",
+				addr.Hex(), artifact.Contract)
+		}
+	}
+	return nil
+}
+
+// pushedConstants walks the bytecode and collects the operands of every PUSH
+// instruction: up to four bytes wide as candidate function selectors (the
+// dispatcher compares calldata against these, using a shorter PUSH when a
+// selector has leading zero bytes) and wider ones as candidate event topics.
+func pushedConstants(code []byte) (map[[4]byte]struct{}, map[common.Hash]struct{}) {
+	selectors := make(map[[4]byte]struct{})
+	topics := make(map[common.Hash]struct{})
+	for pc := 0; pc < len(code); pc++ {
+		op := code[pc]
+		if op < opPush1 || op > opPush32 {
+			continue
+		}
+		n := int(op-opPush1) + 1
+		if pc+n >= len(code) {
+			break
+		}
+		operand := code[pc+1 : pc+1+n]
+		if n <= 4 {
+			selectors[[4]byte(common.LeftPadBytes(operand, 4))] = struct{}{}
+		} else {
+			topics[common.BytesToHash(operand)] = struct{}{}
+		}
+		pc += n
+	}
+	return selectors, topics
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// load runs Load with only env set among the settings' environment
+// variables and yaml, if not empty, as the config file.
+func load(t *testing.T, env map[string]string, yaml string, args ...string) (*Config, error) {
+	t.Helper()
+	for _, s := range append(settings, setting{env: "CONFIG_FILE"}) {
+		t.Setenv(s.env, "")
+		os.Unsetenv(s.env)
+	}
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+	if yaml != "" {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		args = append([]string{"-config", path}, args...)
+	}
+	return Load(args)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	const file = "start_block: 5\nhttp_addr: \":9000\"\n"
+	tests := []struct {
+		name  string
+		env   map[string]string
+		yaml  string
+		args  []string
+		start uint64
+		addr  string
+	}{
+		{name: "defaults", start: 0, addr: ":8080"},
+		{name: "file", yaml: file, start: 5, addr: ":9000"},
+		{name: "env over file", env: map[string]string{"START_BLOCK": "7"}, yaml: file, start: 7, addr: ":9000"},
+		{name: "flag over env", env: map[string]string{"START_BLOCK": "7", "HTTP_ADDR": ":9001"}, yaml: file, args: []string{"-start-block", "9"}, start: 9, addr: ":9001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := load(t, tt.env, tt.yaml, tt.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(cfg.Chains) != 1 || cfg.Chains[0].StartBlock != tt.start || cfg.HTTPAddr != tt.addr {
+				t.Errorf("start block %d, HTTP address %q; want %d, %q", cfg.Chains[0].StartBlock, cfg.HTTPAddr, tt.start, tt.addr)
+			}
+		})
+	}
+}
+
+// The variables .env.example sets don't clash with a chains list: the
+// entries keep what they set and the rest is filled in from the
+// environment.
+func TestLoadChainsOverEnv(t *testing.T) {
+	env := map[string]string{
+		"RPC_URL":            "ws://127.0.0.1:8545",
+		"CHAIN_ID":           "0",
+		"START_BLOCK":        "0",
+		"CONFIRMATION_DEPTH": "3",
+		// Empty contract addresses are looked up in Ignition's record.
+		"ESCROW_FACTORY_ADDRESS":   "",
+		"ARBITER_REGISTRY_ADDRESS": "",
+	}
+	yaml := `
+start_block: 100
+chains:
+  - chain_id: 31337
+  - chain_id: 11155111
+    rpc_url: https://sepolia.example.org
+    start_block: 7000000
+`
+	cfg, err := load(t, env, yaml, "-confirmation-depth", "12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Chains) != 2 {
+		t.Fatalf("got %d chains, want 2", len(cfg.Chains))
+	}
+	local, sepolia := cfg.Chains[0], cfg.Chains[1]
+	if local.ChainID != 31337 || local.RPCURLs[0] != "ws://127.0.0.1:8545" || local.StartBlock != 0 || local.ConfirmationDepth != 12 {
+		t.Errorf("local chain = %+v", local)
+	}
+	if sepolia.ChainID != 11155111 || sepolia.RPCURLs[0] != "https://sepolia.example.org" || sepolia.StartBlock != 7000000 || sepolia.ConfirmationDepth != 12 {
+		t.Errorf("sepolia chain = %+v", sepolia)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		yaml string
+		args []string
+		want []string
+	}{
+		{
+			name: "database URL",
+			env:  map[string]string{"DATABASE_URL": "mysql://localhost"},
+			want: []string{"DATABASE_URL: must be a postgres://, sqlite: or memory: URL"},
+		},
+		{
+			name: "RPC URL scheme",
+			env:  map[string]string{"RPC_URL": "ftp://node"},
+			want: []string{"RPC_URL: every endpoint must be a ws(s):// or http(s):// URL"},
+		},
+		{
+			name: "quorum above endpoints",
+			args: []string{"-rpc-quorum", "2"},
+			want: []string{"RPC_QUORUM: 2 is more than the 1 endpoint(s) in RPC_URL"},
+		},
+		{
+			name: "every problem is reported",
+			env:  map[string]string{"START_BLOCK": "-1", "POLL_INTERVAL": "soon", "ESCROW_FACTORY_ADDRESS": "0x1", "HTTP_ADDR": ""},
+			want: []string{"START_BLOCK: \"-1\" is not a non-negative integer", "POLL_INTERVAL: \"soon\" is not a positive duration", "ESCROW_FACTORY_ADDRESS: \"0x1\" is not an address", "HTTP_ADDR: is not set"},
+		},
+		{
+			name: "chain ID missing from a list",
+			yaml: "chains:\n  - chain_id: 1\n  - rpc_url: ws://other\n",
+			want: []string{"chains[1]: CHAIN_ID: must be set when several chains are configured"},
+		},
+		{
+			name: "chain configured twice",
+			yaml: "chains:\n  - chain_id: 1\n  - chain_id: 1\n",
+			want: []string{"chains[1]: CHAIN_ID: chain 1 is configured twice"},
+		},
+		{
+			name: "global key in a chain entry",
+			yaml: "chains:\n  - chain_id: 1\n    http_addr: \":1\"\n",
+			want: []string{"chains[0]: http_addr applies to every chain and must be set at the top level"},
+		},
+		{
+			name: "unknown key",
+			yaml: "rpc_urls: ws://node\n",
+			want: []string{"unknown key rpc_urls"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := load(t, tt.env, tt.yaml, tt.args...)
+			if err == nil {
+				t.Fatal("Load succeeded")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q doesn't mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+// Ignition is only read for addresses that aren't configured, so a contract
+// deployed by several modules doesn't matter once its address is set.
+func TestResolveContracts(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "chain-31337"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	deployed := `{
+  "Escrow#EscrowFactory": "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+  "Platform#EscrowFactory": "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512",
+  "Platform#ArbiterRegistry": "0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0"
+}`
+	if err := os.WriteFile(filepath.Join(dir, "chain-31337", "deployed_addresses.json"), []byte(deployed), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := load(t, map[string]string{"ESCROW_FACTORY_ADDRESS": "0x5FbDB2315678afecb367f032d93F642f64180aa3"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch := cfg.Chains[0]
+	if err := ch.ResolveContracts(dir, 31337); err != nil {
+		t.Fatal(err)
+	}
+	if ch.EscrowFactory.Hex() != "0x5FbDB2315678afecb367f032d93F642f64180aa3" || ch.ArbiterRegistry.Hex() != "0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0" {
+		t.Errorf("factory %s, registry %s", ch.EscrowFactory.Hex(), ch.ArbiterRegistry.Hex())
+	}
+
+	cfg, err = load(t, map[string]string{"ESCROW_FACTORY_ADDRESS": ""}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Chains[0].ResolveContracts(dir, 31337); err == nil || !strings.Contains(err.Error(), "EscrowFactory is deployed at both") {
+		t.Errorf("unset ambiguous factory: error %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ResolveContracts fills in contract addresses that weren't configured from
+// Ignition's deployment record for chainID under ignitionDir, and prints
+// where each address came from. Ignition is only consulted for addresses
+// that aren't configured; an empty one counts as not configured.
+func (c *Chain) ResolveContracts(ignitionDir string, chainID uint64) error {
+	path := filepath.Join(ignitionDir, fmt.Sprintf("chain-%d", chainID), "deployed_addresses.json")
+	contracts := []struct {
+		env      string
+		contract string
+		addr     *common.Address
+	}{
+		{"ESCROW_FACTORY_ADDRESS", "EscrowFactory", &c.EscrowFactory},
+		{"ARBITER_REGISTRY_ADDRESS", "ArbiterRegistry", &c.ArbiterRegistry},
+		{"USER_PROFILE_ADDRESS", "UserProfile", &c.UserProfile},
+	}
+	var (
+		deployed deployedAddresses
+		read     bool
+		errs     []error
+	)
+	for _, ct := range contracts {
+		if source, ok := c.sources[ct.env]; ok && *ct.addr != (common.Address{}) {
+			fmt.Printf("📇 [chain %d] %s: %s from %s\n", chainID, ct.contract, ct.addr.Hex(), source)
+			continue
+		}
+		if !read {
+			var err error
+			if deployed, err = readDeployedAddresses(path); err != nil {
+				return err
+			}
+			read = true
+		}
+		found, err := deployed.lookup(ct.contract)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		if found != nil {
+			*ct.addr = *found
+			fmt.Printf("📇 [chain %d] %s: %s from %s\n", chainID, ct.contract, found.Hex(), path)
+		}
+	}
+	if c.EscrowFactory == (common.Address{}) {
+		errs = append(errs, fmt.Errorf("ESCROW_FACTORY_ADDRESS: is not set and %s has no EscrowFactory", path))
+	}
+	return errors.Join(errs...)
+}
+
+// deployedAddresses is Ignition's deployed_addresses.json, which maps
+// "<Module>#<Contract>" to an address.
+type deployedAddresses map[string]string
+
+func readDeployedAddresses(path string) (deployedAddresses, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read Ignition deployments: %w", err)
+	}
+	var deployed deployedAddresses
+	if err := json.Unmarshal(raw, &deployed); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return deployed, nil
+}
+
+// lookup returns the address of contract in any module, or nil if it wasn't
+// deployed. Different addresses in different modules are ambiguous.
+func (d deployedAddresses) lookup(contract string) (*common.Address, error) {
+	var found *common.Address
+	for key, v := range d {
+		if !strings.HasSuffix(key, "#"+contract) {
+			continue
+		}
+		if !common.IsHexAddress(v) {
+			return nil, fmt.Errorf("%s: %q is not an address", key, v)
+		}
+		addr := common.HexToAddress(v)
+		if found != nil && *found != addr {
+			return nil, fmt.Errorf("%s is deployed at both %s and %s; set its address explicitly", contract, found.Hex(), addr.Hex())
+		}
+		found = &addr
+	}
+	return found, nil
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS indexer_checkpoints;
\ No newline at end of file
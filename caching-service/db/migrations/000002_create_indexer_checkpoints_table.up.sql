@@ -0,0 +1,8 @@
+CREATE TABLE indexer_checkpoints (
+    chain_id BIGINT NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    block_number BIGINT NOT NULL,
+    log_index INT NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (chain_id, contract_address)
+);
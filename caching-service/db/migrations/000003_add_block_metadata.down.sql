@@ -0,0 +1,10 @@
+ALTER TABLE indexer_checkpoints
+    DROP COLUMN IF EXISTS block_hash;
+
+DROP INDEX IF EXISTS deals_block_number_idx;
+
+ALTER TABLE deals
+    DROP COLUMN IF EXISTS log_index,
+    DROP COLUMN IF EXISTS tx_hash,
+    DROP COLUMN IF EXISTS block_hash,
+    DROP COLUMN IF EXISTS block_number;
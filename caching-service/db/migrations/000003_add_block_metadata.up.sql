@@ -0,0 +1,10 @@
+ALTER TABLE deals
+    ADD COLUMN block_number BIGINT,
+    ADD COLUMN block_hash VARCHAR(66),
+    ADD COLUMN tx_hash VARCHAR(66),
+    ADD COLUMN log_index INT;
+
+CREATE INDEX deals_block_number_idx ON deals (block_number);
+
+ALTER TABLE indexer_checkpoints
+    ADD COLUMN block_hash VARCHAR(66);
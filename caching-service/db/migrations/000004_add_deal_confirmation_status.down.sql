@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS deals_pending_idx;
+
+ALTER TABLE deals
+    DROP COLUMN IF EXISTS confirmation_status;
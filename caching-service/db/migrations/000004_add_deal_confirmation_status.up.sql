@@ -0,0 +1,10 @@
+-- Rows that already exist were ingested without a confirmation depth, so they
+-- are treated as final. New rows start out pending.
+ALTER TABLE deals
+    ADD COLUMN confirmation_status VARCHAR(16) NOT NULL DEFAULT 'confirmed'
+        CHECK (confirmation_status IN ('pending', 'confirmed'));
+
+ALTER TABLE deals
+    ALTER COLUMN confirmation_status SET DEFAULT 'pending';
+
+CREATE INDEX deals_pending_idx ON deals (block_number) WHERE confirmation_status = 'pending';
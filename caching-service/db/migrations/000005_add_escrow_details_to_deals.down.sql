@@ -0,0 +1,3 @@
+ALTER TABLE deals
+    DROP COLUMN IF EXISTS project_description,
+    DROP COLUMN IF EXISTS token_address;
@@ -0,0 +1,3 @@
+ALTER TABLE deals
+    ADD COLUMN token_address VARCHAR(42),
+    ADD COLUMN project_description TEXT;
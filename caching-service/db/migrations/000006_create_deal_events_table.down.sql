@@ -0,0 +1 @@
+DROP TABLE IF EXISTS deal_events;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE deal_events (
+    id SERIAL PRIMARY KEY,
+    contract_address VARCHAR(42) NOT NULL REFERENCES deals (contract_address) ON DELETE CASCADE,
+    event_name VARCHAR(32) NOT NULL,
+    actor_address VARCHAR(42),
+    amount TEXT,
+    work_submission TEXT,
+    status INT NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX deal_events_contract_idx ON deal_events (contract_address, block_number, log_index);
+CREATE INDEX deal_events_block_number_idx ON deal_events (block_number);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS indexed_logs;
\ No newline at end of file
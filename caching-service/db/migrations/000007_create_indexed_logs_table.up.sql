@@ -0,0 +1,22 @@
+CREATE TABLE indexed_logs (
+    chain_id BIGINT NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INT NOT NULL,
+    PRIMARY KEY (chain_id, contract_address, block_number, log_index)
+);
+
+CREATE INDEX indexed_logs_block_hash_idx ON indexed_logs (block_hash);
+
+-- Until now only the escrow factory was indexed, so every existing deal and
+-- deal event belongs to the one checkpoint that exists.
+INSERT INTO indexed_logs (chain_id, contract_address, block_number, block_hash, tx_hash, log_index)
+SELECT c.chain_id, c.contract_address, d.block_number, d.block_hash, d.tx_hash, d.log_index
+FROM deals d CROSS JOIN indexer_checkpoints c
+WHERE d.block_number IS NOT NULL
+UNION ALL
+SELECT c.chain_id, c.contract_address, e.block_number, e.block_hash, e.tx_hash, e.log_index
+FROM deal_events e CROSS JOIN indexer_checkpoints c
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS registry_events;
+DROP TABLE IF EXISTS arbiters;
\ No newline at end of file
@@ -0,0 +1,28 @@
+CREATE TABLE arbiters (
+    address VARCHAR(42) PRIMARY KEY,
+    name TEXT NOT NULL,
+    profile_hash TEXT,
+    is_active BOOLEAN NOT NULL,
+    added_block BIGINT NOT NULL,
+    removed_block BIGINT,
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX arbiters_active_idx ON arbiters (address) WHERE is_active;
+
+CREATE TABLE registry_events (
+    id SERIAL PRIMARY KEY,
+    event_name VARCHAR(32) NOT NULL,
+    address VARCHAR(42) NOT NULL,
+    name TEXT,
+    profile_hash TEXT,
+    previous_address VARCHAR(42),
+    block_number BIGINT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX registry_events_address_idx ON registry_events (address, block_number, log_index);
+CREATE INDEX registry_events_block_number_idx ON registry_events (block_number);
@@ -0,0 +1,3 @@
+DROP VIEW IF EXISTS deals_with_profiles;
+DROP TABLE IF EXISTS profile_events;
+DROP TABLE IF EXISTS profiles;
\ No newline at end of file
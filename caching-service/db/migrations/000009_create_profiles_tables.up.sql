@@ -0,0 +1,38 @@
+CREATE TABLE profiles (
+    address VARCHAR(42) PRIMARY KEY,
+    username TEXT NOT NULL,
+    bio TEXT NOT NULL,
+    profile_image_hash TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL,
+    block_number BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE profile_events (
+    id SERIAL PRIMARY KEY,
+    event_name VARCHAR(32) NOT NULL,
+    address VARCHAR(42) NOT NULL,
+    username TEXT,
+    bio TEXT,
+    profile_image_hash TEXT,
+    block_number BIGINT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX profile_events_address_idx ON profile_events (address, block_number, log_index);
+CREATE INDEX profile_events_block_number_idx ON profile_events (block_number);
+
+-- Deleted profiles are kept in profiles for their history but must not be
+-- shown, so only active ones are joined.
+CREATE VIEW deals_with_profiles AS
+SELECT d.*,
+    c.username AS client_username,
+    c.profile_image_hash AS client_avatar_hash,
+    f.username AS freelancer_username,
+    f.profile_image_hash AS freelancer_avatar_hash
+FROM deals d
+LEFT JOIN profiles c ON c.address = d.client_address AND c.is_active
+LEFT JOIN profiles f ON f.address = d.freelancer_address AND f.is_active;
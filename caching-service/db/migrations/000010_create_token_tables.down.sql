@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS token_balances;
+DROP TABLE IF EXISTS token_approvals;
+DROP TABLE IF EXISTS token_transfers;
\ No newline at end of file
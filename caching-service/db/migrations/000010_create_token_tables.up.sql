@@ -0,0 +1,44 @@
+CREATE TABLE token_transfers (
+    id SERIAL PRIMARY KEY,
+    token_address VARCHAR(42) NOT NULL,
+    from_address VARCHAR(42) NOT NULL,
+    to_address VARCHAR(42) NOT NULL,
+    amount NUMERIC(78, 0) NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX token_transfers_from_idx ON token_transfers (from_address, block_number);
+CREATE INDEX token_transfers_to_idx ON token_transfers (to_address, block_number);
+CREATE INDEX token_transfers_token_block_idx ON token_transfers (token_address, block_number);
+
+CREATE TABLE token_approvals (
+    id SERIAL PRIMARY KEY,
+    token_address VARCHAR(42) NOT NULL,
+    owner_address VARCHAR(42) NOT NULL,
+    spender_address VARCHAR(42) NOT NULL,
+    amount NUMERIC(78, 0) NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX token_approvals_owner_idx ON token_approvals (owner_address, spender_address, block_number);
+CREATE INDEX token_approvals_token_block_idx ON token_approvals (token_address, block_number);
+
+-- Balances are derived from token_transfers; mints and burns only move the
+-- non-zero side.
+CREATE TABLE token_balances (
+    token_address VARCHAR(42) NOT NULL,
+    holder_address VARCHAR(42) NOT NULL,
+    balance NUMERIC(78, 0) NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (token_address, holder_address)
+);
+
+CREATE INDEX token_balances_holder_idx ON token_balances (holder_address);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS user_escrows;
\ No newline at end of file
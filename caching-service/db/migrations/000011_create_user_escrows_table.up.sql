@@ -0,0 +1,20 @@
+CREATE TABLE user_escrows (
+    user_address VARCHAR(42) NOT NULL,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('client', 'freelancer', 'arbiter')),
+    escrow_address VARCHAR(42) NOT NULL,
+    deal_id INT NOT NULL REFERENCES deals (id) ON DELETE CASCADE,
+    PRIMARY KEY (user_address, role, escrow_address)
+);
+
+-- Serves "a user's deals, newest first" with or without a role filter.
+CREATE INDEX user_escrows_user_deal_idx ON user_escrows (user_address, deal_id DESC);
+CREATE INDEX user_escrows_user_role_deal_idx ON user_escrows (user_address, role, deal_id DESC);
+
+INSERT INTO user_escrows (user_address, role, escrow_address, deal_id)
+SELECT client_address, 'client', contract_address, id FROM deals
+UNION ALL
+SELECT freelancer_address, 'freelancer', contract_address, id FROM deals
+UNION ALL
+SELECT arbiter_address, 'arbiter', contract_address, id FROM deals
+WHERE arbiter_address <> '0x0000000000000000000000000000000000000000'
+ON CONFLICT DO NOTHING;
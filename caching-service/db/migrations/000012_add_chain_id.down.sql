@@ -0,0 +1,83 @@
+-- Without chain_id the rows of different chains can't be told apart and
+-- would collide, so this is refused rather than dropping all but one chain.
+-- Empty the tables of the chains to give up first.
+DO $$
+DECLARE
+    chains BIGINT;
+BEGIN
+    SELECT COUNT(DISTINCT chain_id) INTO chains FROM (
+        SELECT chain_id FROM deals
+        UNION SELECT chain_id FROM deal_events
+        UNION SELECT chain_id FROM user_escrows
+        UNION SELECT chain_id FROM registry_events
+        UNION SELECT chain_id FROM arbiters
+        UNION SELECT chain_id FROM profile_events
+        UNION SELECT chain_id FROM profiles
+        UNION SELECT chain_id FROM token_transfers
+        UNION SELECT chain_id FROM token_approvals
+        UNION SELECT chain_id FROM token_balances
+    ) AS used;
+    IF chains > 1 THEN
+        RAISE EXCEPTION 'the database holds data from % chains; chain_id can only be dropped with one', chains;
+    END IF;
+END $$;
+
+DROP VIEW deals_with_profiles;
+
+DROP INDEX deals_chain_idx;
+DROP INDEX deals_block_number_idx;
+CREATE INDEX deals_block_number_idx ON deals (block_number);
+DROP INDEX deals_pending_idx;
+CREATE INDEX deals_pending_idx ON deals (block_number) WHERE confirmation_status = 'pending';
+DROP INDEX deal_events_contract_idx;
+CREATE INDEX deal_events_contract_idx ON deal_events (contract_address, block_number, log_index);
+DROP INDEX deal_events_block_number_idx;
+CREATE INDEX deal_events_block_number_idx ON deal_events (block_number);
+DROP INDEX registry_events_address_idx;
+CREATE INDEX registry_events_address_idx ON registry_events (address, block_number, log_index);
+DROP INDEX registry_events_block_number_idx;
+CREATE INDEX registry_events_block_number_idx ON registry_events (block_number);
+DROP INDEX profile_events_address_idx;
+CREATE INDEX profile_events_address_idx ON profile_events (address, block_number, log_index);
+DROP INDEX profile_events_block_number_idx;
+CREATE INDEX profile_events_block_number_idx ON profile_events (block_number);
+DROP INDEX token_transfers_token_block_idx;
+CREATE INDEX token_transfers_token_block_idx ON token_transfers (token_address, block_number);
+DROP INDEX token_approvals_token_block_idx;
+CREATE INDEX token_approvals_token_block_idx ON token_approvals (token_address, block_number);
+
+ALTER TABLE deal_events DROP CONSTRAINT deal_events_chain_contract_address_fkey;
+ALTER TABLE deals DROP CONSTRAINT deals_chain_contract_address_key;
+ALTER TABLE deals ADD CONSTRAINT deals_contract_address_key UNIQUE (contract_address);
+ALTER TABLE deal_events ADD CONSTRAINT deal_events_contract_address_fkey
+    FOREIGN KEY (contract_address) REFERENCES deals (contract_address) ON DELETE CASCADE;
+
+ALTER TABLE user_escrows DROP CONSTRAINT user_escrows_pkey;
+ALTER TABLE user_escrows ADD PRIMARY KEY (user_address, role, escrow_address);
+ALTER TABLE arbiters DROP CONSTRAINT arbiters_pkey;
+ALTER TABLE arbiters ADD PRIMARY KEY (address);
+ALTER TABLE profiles DROP CONSTRAINT profiles_pkey;
+ALTER TABLE profiles ADD PRIMARY KEY (address);
+ALTER TABLE token_balances DROP CONSTRAINT token_balances_pkey;
+ALTER TABLE token_balances ADD PRIMARY KEY (token_address, holder_address);
+
+ALTER TABLE deals DROP COLUMN chain_id;
+ALTER TABLE deal_events DROP COLUMN chain_id;
+ALTER TABLE user_escrows DROP COLUMN chain_id;
+ALTER TABLE registry_events DROP COLUMN chain_id;
+ALTER TABLE arbiters DROP COLUMN chain_id;
+ALTER TABLE profile_events DROP COLUMN chain_id;
+ALTER TABLE profiles DROP COLUMN chain_id;
+ALTER TABLE token_transfers DROP COLUMN chain_id;
+ALTER TABLE token_approvals DROP COLUMN chain_id;
+ALTER TABLE token_balances DROP COLUMN chain_id;
+
+CREATE VIEW deals_with_profiles AS
+SELECT d.*,
+    c.username AS client_username,
+    c.profile_image_hash AS client_avatar_hash,
+    f.username AS freelancer_username,
+    f.profile_image_hash AS freelancer_avatar_hash
+FROM deals d
+LEFT JOIN profiles c ON c.address = d.client_address AND c.is_active
+LEFT JOIN profiles f ON f.address = d.freelancer_address AND f.is_active;
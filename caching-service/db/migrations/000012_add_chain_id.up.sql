@@ -0,0 +1,116 @@
+-- Every table gets the chain its rows came from, so several chains can be
+-- indexed into one database. Existing rows are matched to the chain of the
+-- indexed log they were derived from; anything that can't be matched
+-- belongs to the only chain indexed so far.
+CREATE FUNCTION pg_temp.default_chain() RETURNS BIGINT AS $$
+    SELECT COALESCE((SELECT MIN(chain_id) FROM indexer_checkpoints), 31337)
+$$ LANGUAGE SQL;
+
+ALTER TABLE deals ADD COLUMN chain_id BIGINT;
+UPDATE deals d SET chain_id = l.chain_id
+FROM indexed_logs l WHERE l.block_hash = d.block_hash AND l.log_index = d.log_index;
+UPDATE deals SET chain_id = pg_temp.default_chain() WHERE chain_id IS NULL;
+
+ALTER TABLE deal_events ADD COLUMN chain_id BIGINT;
+UPDATE deal_events e SET chain_id = d.chain_id FROM deals d WHERE d.contract_address = e.contract_address;
+
+ALTER TABLE user_escrows ADD COLUMN chain_id BIGINT;
+UPDATE user_escrows u SET chain_id = d.chain_id FROM deals d WHERE d.id = u.deal_id;
+
+ALTER TABLE registry_events ADD COLUMN chain_id BIGINT;
+UPDATE registry_events e SET chain_id = l.chain_id
+FROM indexed_logs l WHERE l.block_hash = e.block_hash AND l.log_index = e.log_index;
+UPDATE registry_events SET chain_id = pg_temp.default_chain() WHERE chain_id IS NULL;
+
+ALTER TABLE arbiters ADD COLUMN chain_id BIGINT;
+UPDATE arbiters a SET chain_id = COALESCE(
+    (SELECT e.chain_id FROM registry_events e WHERE e.address = a.address ORDER BY e.block_number DESC LIMIT 1),
+    pg_temp.default_chain());
+
+ALTER TABLE profile_events ADD COLUMN chain_id BIGINT;
+UPDATE profile_events e SET chain_id = l.chain_id
+FROM indexed_logs l WHERE l.block_hash = e.block_hash AND l.log_index = e.log_index;
+UPDATE profile_events SET chain_id = pg_temp.default_chain() WHERE chain_id IS NULL;
+
+ALTER TABLE profiles ADD COLUMN chain_id BIGINT;
+UPDATE profiles p SET chain_id = COALESCE(
+    (SELECT e.chain_id FROM profile_events e WHERE e.address = p.address ORDER BY e.block_number DESC LIMIT 1),
+    pg_temp.default_chain());
+
+ALTER TABLE token_transfers ADD COLUMN chain_id BIGINT;
+UPDATE token_transfers t SET chain_id = l.chain_id
+FROM indexed_logs l WHERE l.block_hash = t.block_hash AND l.log_index = t.log_index;
+UPDATE token_transfers SET chain_id = pg_temp.default_chain() WHERE chain_id IS NULL;
+
+ALTER TABLE token_approvals ADD COLUMN chain_id BIGINT;
+UPDATE token_approvals t SET chain_id = l.chain_id
+FROM indexed_logs l WHERE l.block_hash = t.block_hash AND l.log_index = t.log_index;
+UPDATE token_approvals SET chain_id = pg_temp.default_chain() WHERE chain_id IS NULL;
+
+ALTER TABLE token_balances ADD COLUMN chain_id BIGINT;
+UPDATE token_balances b SET chain_id = COALESCE(
+    (SELECT t.chain_id FROM token_transfers t WHERE t.token_address = b.token_address ORDER BY t.block_number DESC LIMIT 1),
+    pg_temp.default_chain());
+
+ALTER TABLE deals ALTER COLUMN chain_id SET NOT NULL;
+ALTER TABLE deal_events ALTER COLUMN chain_id SET NOT NULL;
+ALTER TABLE user_escrows ALTER COLUMN chain_id SET NOT NULL;
+ALTER TABLE registry_events ALTER COLUMN chain_id SET NOT NULL;
+ALTER TABLE arbiters ALTER COLUMN chain_id SET NOT NULL;
+ALTER TABLE profile_events ALTER COLUMN chain_id SET NOT NULL;
+ALTER TABLE profiles ALTER COLUMN chain_id SET NOT NULL;
+ALTER TABLE token_transfers ALTER COLUMN chain_id SET NOT NULL;
+ALTER TABLE token_approvals ALTER COLUMN chain_id SET NOT NULL;
+ALTER TABLE token_balances ALTER COLUMN chain_id SET NOT NULL;
+
+-- The same deployer reaches the same addresses on every chain, so addresses
+-- are only unique per chain.
+ALTER TABLE deal_events DROP CONSTRAINT deal_events_contract_address_fkey;
+ALTER TABLE deals DROP CONSTRAINT deals_contract_address_key;
+ALTER TABLE deals ADD CONSTRAINT deals_chain_contract_address_key UNIQUE (chain_id, contract_address);
+ALTER TABLE deal_events ADD CONSTRAINT deal_events_chain_contract_address_fkey
+    FOREIGN KEY (chain_id, contract_address) REFERENCES deals (chain_id, contract_address) ON DELETE CASCADE;
+
+ALTER TABLE user_escrows DROP CONSTRAINT user_escrows_pkey;
+ALTER TABLE user_escrows ADD PRIMARY KEY (chain_id, user_address, role, escrow_address);
+ALTER TABLE arbiters DROP CONSTRAINT arbiters_pkey;
+ALTER TABLE arbiters ADD PRIMARY KEY (chain_id, address);
+ALTER TABLE profiles DROP CONSTRAINT profiles_pkey;
+ALTER TABLE profiles ADD PRIMARY KEY (chain_id, address);
+ALTER TABLE token_balances DROP CONSTRAINT token_balances_pkey;
+ALTER TABLE token_balances ADD PRIMARY KEY (chain_id, token_address, holder_address);
+
+-- Rollbacks delete by chain and block.
+DROP INDEX deals_block_number_idx;
+CREATE INDEX deals_block_number_idx ON deals (chain_id, block_number);
+DROP INDEX deals_pending_idx;
+CREATE INDEX deals_pending_idx ON deals (chain_id, block_number) WHERE confirmation_status = 'pending';
+DROP INDEX deal_events_contract_idx;
+CREATE INDEX deal_events_contract_idx ON deal_events (chain_id, contract_address, block_number, log_index);
+DROP INDEX deal_events_block_number_idx;
+CREATE INDEX deal_events_block_number_idx ON deal_events (chain_id, block_number);
+DROP INDEX registry_events_address_idx;
+CREATE INDEX registry_events_address_idx ON registry_events (chain_id, address, block_number, log_index);
+DROP INDEX registry_events_block_number_idx;
+CREATE INDEX registry_events_block_number_idx ON registry_events (chain_id, block_number);
+DROP INDEX profile_events_address_idx;
+CREATE INDEX profile_events_address_idx ON profile_events (chain_id, address, block_number, log_index);
+DROP INDEX profile_events_block_number_idx;
+CREATE INDEX profile_events_block_number_idx ON profile_events (chain_id, block_number);
+DROP INDEX token_transfers_token_block_idx;
+CREATE INDEX token_transfers_token_block_idx ON token_transfers (chain_id, token_address, block_number);
+DROP INDEX token_approvals_token_block_idx;
+CREATE INDEX token_approvals_token_block_idx ON token_approvals (chain_id, token_address, block_number);
+CREATE INDEX deals_chain_idx ON deals (chain_id, id DESC);
+
+-- Profiles are per chain too, so the join has to match it.
+DROP VIEW deals_with_profiles;
+CREATE VIEW deals_with_profiles AS
+SELECT d.*,
+    c.username AS client_username,
+    c.profile_image_hash AS client_avatar_hash,
+    f.username AS freelancer_username,
+    f.profile_image_hash AS freelancer_avatar_hash
+FROM deals d
+LEFT JOIN profiles c ON c.chain_id = d.chain_id AND c.address = d.client_address AND c.is_active
+LEFT JOIN profiles f ON f.chain_id = d.chain_id AND f.address = d.freelancer_address AND f.is_active;
@@ -0,0 +1,6 @@
+DROP INDEX token_approvals_log_key;
+DROP INDEX token_transfers_log_key;
+DROP INDEX profile_events_log_key;
+DROP INDEX registry_events_log_key;
+DROP INDEX deal_events_log_key;
+DROP INDEX deals_log_key;
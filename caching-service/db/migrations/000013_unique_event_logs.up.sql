@@ -0,0 +1,53 @@
+-- Every ingested event is identified by the log it came from, so a replayed
+-- log is recognised and skipped instead of applied a second time.
+--
+-- Replays before this migration could store an event twice. The copies are
+-- removed first, and what they may have moved twice is recomputed.
+
+CREATE TEMPORARY TABLE replayed_deals ON COMMIT DROP AS
+SELECT DISTINCT a.chain_id, a.contract_address FROM deal_events a
+JOIN deal_events b ON b.chain_id = a.chain_id AND b.tx_hash = a.tx_hash AND b.log_index = a.log_index AND b.id < a.id;
+
+CREATE TEMPORARY TABLE replayed_tokens ON COMMIT DROP AS
+SELECT DISTINCT a.chain_id, a.token_address FROM token_transfers a
+JOIN token_transfers b ON b.chain_id = a.chain_id AND b.tx_hash = a.tx_hash AND b.log_index = a.log_index AND b.id < a.id;
+
+DELETE FROM deal_events a USING deal_events b
+WHERE b.chain_id = a.chain_id AND b.tx_hash = a.tx_hash AND b.log_index = a.log_index AND b.id < a.id;
+DELETE FROM registry_events a USING registry_events b
+WHERE b.chain_id = a.chain_id AND b.tx_hash = a.tx_hash AND b.log_index = a.log_index AND b.id < a.id;
+DELETE FROM profile_events a USING profile_events b
+WHERE b.chain_id = a.chain_id AND b.tx_hash = a.tx_hash AND b.log_index = a.log_index AND b.id < a.id;
+DELETE FROM token_transfers a USING token_transfers b
+WHERE b.chain_id = a.chain_id AND b.tx_hash = a.tx_hash AND b.log_index = a.log_index AND b.id < a.id;
+DELETE FROM token_approvals a USING token_approvals b
+WHERE b.chain_id = a.chain_id AND b.tx_hash = a.tx_hash AND b.log_index = a.log_index AND b.id < a.id;
+
+-- A replayed event could move a deal back to an earlier status; the latest
+-- event decides.
+UPDATE deals d SET status = e.status
+FROM replayed_deals r, LATERAL (
+    SELECT status FROM deal_events e
+    WHERE e.chain_id = r.chain_id AND e.contract_address = r.contract_address
+    ORDER BY e.block_number DESC, e.log_index DESC
+    LIMIT 1
+) e
+WHERE d.chain_id = r.chain_id AND d.contract_address = r.contract_address;
+
+-- A replayed transfer moved its amount twice. Balances are the sum of the
+-- transfers, as long as the token was indexed from its deployment.
+UPDATE token_balances b SET balance =
+    COALESCE((SELECT SUM(amount) FROM token_transfers t
+        WHERE t.chain_id = b.chain_id AND t.token_address = b.token_address AND t.to_address = b.holder_address), 0)
+    - COALESCE((SELECT SUM(amount) FROM token_transfers t
+        WHERE t.chain_id = b.chain_id AND t.token_address = b.token_address AND t.from_address = b.holder_address), 0),
+    updated_at = NOW()
+FROM replayed_tokens r
+WHERE b.chain_id = r.chain_id AND b.token_address = r.token_address;
+
+CREATE UNIQUE INDEX deals_log_key ON deals (chain_id, tx_hash, log_index);
+CREATE UNIQUE INDEX deal_events_log_key ON deal_events (chain_id, tx_hash, log_index);
+CREATE UNIQUE INDEX registry_events_log_key ON registry_events (chain_id, tx_hash, log_index);
+CREATE UNIQUE INDEX profile_events_log_key ON profile_events (chain_id, tx_hash, log_index);
+CREATE UNIQUE INDEX token_transfers_log_key ON token_transfers (chain_id, tx_hash, log_index);
+CREATE UNIQUE INDEX token_approvals_log_key ON token_approvals (chain_id, tx_hash, log_index);
@@ -0,0 +1,299 @@
+// Package migrations embeds the caching service's SQL migrations and applies
+// them to Postgres.
+//
+// Applied versions are tracked in schema_migrations using the same layout as
+// golang-migrate, so a database migrated by hand with its CLI carries on
+// from where it is.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// lockKey is the Postgres advisory lock held while migrating, so concurrent
+// instances starting together apply each migration once.
+const lockKey = 0x657363726f77 // "escrow"
+
+// Migration is one numbered schema change.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// All returns the embedded migrations in version order.
+func All() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[uint64]*Migration)
+	for _, e := range entries {
+		m := fileName.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("migration %s: name must be <version>_<name>.(up|down).sql", e.Name())
+		}
+		version, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", e.Name(), err)
+		}
+		body, err := files.ReadFile(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	all := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", mig.Version, mig.Name)
+		}
+		all = append(all, *mig)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Version < all[j].Version })
+	return all, nil
+}
+
+// Migrator applies the embedded migrations to a database.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New returns a Migrator for db.
+func New(db *sql.DB) (*Migrator, error) {
+	all, err := All()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: all}, nil
+}
+
+// Status is the database's position in the migration sequence.
+type Status struct {
+	// Version is the last applied migration, or zero if none is.
+	Version uint64
+	// Dirty means a migration failed part way under golang-migrate and the
+	// schema has to be repaired by hand before forcing a version.
+	Dirty   bool
+	Pending []Migration
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.locked(ctx, func(conn *sql.Conn) error {
+		version, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if version == 0 {
+			if err := checkUntracked(ctx, conn); err != nil {
+				return err
+			}
+		}
+		for _, mig := range m.migrations {
+			if mig.Version <= version {
+				continue
+			}
+			if err := apply(ctx, conn, mig.Up, mig.Version); err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", mig.Version, mig.Name, err)
+			}
+			fmt.Printf("🗄️ Applied migration %d_%s\n", mig.Version, mig.Name)
+		}
+		return nil
+	})
+}
+
+// Down reverts the last n applied migrations.
+func (m *Migrator) Down(ctx context.Context, n int) error {
+	return m.locked(ctx, func(conn *sql.Conn) error {
+		version, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && n > 0; i-- {
+			mig := m.migrations[i]
+			if mig.Version > version {
+				continue
+			}
+			var previous uint64
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+			if err := apply(ctx, conn, mig.Down, previous); err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", mig.Version, mig.Name, err)
+			}
+			fmt.Printf("🗄️ Reverted migration %d_%s\n", mig.Version, mig.Name)
+			n--
+		}
+		return nil
+	})
+}
+
+// Force records version as applied without running anything. It is how a
+// database migrated by hand is adopted, or a dirty one marked as repaired.
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("no migration has version %d", version)
+	}
+	return m.locked(ctx, func(conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+		if err := setVersion(ctx, tx, version); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// Status reports the applied version and the migrations still pending.
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	var st Status
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		var err error
+		st.Version, st.Dirty, err = currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if mig.Version > st.Version {
+				st.Pending = append(st.Pending, mig)
+			}
+		}
+		return nil
+	})
+	return st, err
+}
+
+func (m *Migrator) known(version uint64) bool {
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// locked runs fn on a single connection holding the migration lock.
+// Advisory locks belong to a session, so everything has to go through
+// that connection rather than the pool.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("lock migrations: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+
+	if _, err := conn.ExecContext(ctx, `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		dirty BOOLEAN NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return fn(conn)
+}
+
+// cleanVersion returns the applied version, refusing to go on from a
+// dirty or unknown one.
+func (m *Migrator) cleanVersion(ctx context.Context, conn *sql.Conn) (uint64, error) {
+	version, dirty, err := currentVersion(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("database is dirty at version %d; repair the schema and run `migrate force %d`", version, version)
+	}
+	if version != 0 && !m.known(version) {
+		return 0, fmt.Errorf("database is at version %d, which this build doesn't know; it was migrated by a newer release", version)
+	}
+	return version, nil
+}
+
+func currentVersion(ctx context.Context, conn *sql.Conn) (version uint64, dirty bool, err error) {
+	err = conn.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// checkUntracked refuses to migrate a database whose tables were created by
+// hand, since the first migration would fail on them part way through.
+func checkUntracked(ctx context.Context, conn *sql.Conn) error {
+	var exists bool
+	if err := conn.QueryRowContext(ctx, `SELECT to_regclass('deals') IS NOT NULL`).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("the deals table exists but schema_migrations has no version; run `migrate force <version>` with the last migration applied by hand")
+	}
+	return nil
+}
+
+// apply runs one migration and records version in the same transaction, so
+// a failed migration leaves neither the schema nor the version changed.
+func apply(ctx context.Context, conn *sql.Conn, body string, version uint64) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		return err
+	}
+	if err := setVersion(ctx, tx, version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func setVersion(ctx context.Context, tx *sql.Tx, version uint64) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return fmt.Errorf("record version: %w", err)
+	}
+	if version == 0 {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, version); err != nil {
+		return fmt.Errorf("record version: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func TestAll(t *testing.T) {
+	all, err := All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+	for i, mig := range all {
+		if want := uint64(i + 1); mig.Version != want {
+			t.Errorf("migration %d_%s: version %d, want %d (versions must be consecutive)", mig.Version, mig.Name, mig.Version, want)
+		}
+	}
+}
+
+// testDatabase connects to a new, empty schema on the server at
+// POSTGRES_TEST_URL, skipping the test if it isn't set.
+func testDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+	base := os.Getenv("POSTGRES_TEST_URL")
+	if base == "" {
+		t.Skip("POSTGRES_TEST_URL is not set")
+	}
+	schema := fmt.Sprintf("migrations_test_%d", time.Now().UnixNano())
+	exec := func(query string) {
+		db, err := sql.Open("postgres", base)
+		if err == nil {
+			_, err = db.Exec(query)
+			db.Close()
+		}
+		if err != nil {
+			t.Errorf("%s: %v", query, err)
+		}
+	}
+	exec(`CREATE SCHEMA ` + schema)
+	t.Cleanup(func() { exec(`DROP SCHEMA ` + schema + ` CASCADE`) })
+
+	u, err := url.Parse(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	db, err := sql.Open("postgres", u.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestPostgres(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+	m, err := New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := m.migrations[len(m.migrations)-1].Version
+	version := func() uint64 {
+		t.Helper()
+		st, err := m.Status(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.Dirty || len(st.Pending) != int(latest-st.Version) {
+			t.Errorf("status = %+v", st)
+		}
+		return st.Version
+	}
+
+	if v := version(); v != 0 {
+		t.Fatalf("new database at version %d", v)
+	}
+	if err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if v := version(); v != latest {
+		t.Fatalf("at version %d after up, want %d", v, latest)
+	}
+
+	// Every down migration undoes its up migration.
+	if err := m.Down(ctx, len(m.migrations)); err != nil {
+		t.Fatal(err)
+	}
+	if v := version(); v != 0 {
+		t.Fatalf("at version %d after going all the way down", v)
+	}
+	if err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// chain_id can't be dropped while two chains share the database.
+	for _, chainID := range []int64{1, 31337} {
+		if _, err := db.ExecContext(ctx, `
+		INSERT INTO arbiters (chain_id, address, name, is_active, added_block)
+		VALUES ($1, '0x90F79bf6EB2c4f870365E785982E1f101E93b906', 'Arbiter', true, 1)`, chainID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = m.Down(ctx, 2)
+	if err == nil || !strings.Contains(err.Error(), "holds data from 2 chains") {
+		t.Fatalf("down past chain_id with two chains: %v", err)
+	}
+	if v := version(); v != 12 {
+		t.Fatalf("at version %d after the refused down migration, want 12", v)
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM arbiters WHERE chain_id = 1`); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Down(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	var arbiters int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM arbiters`).Scan(&arbiters); err != nil || arbiters != 1 {
+		t.Errorf("%d arbiters left after dropping chain_id, %v", arbiters, err)
+	}
+
+	// Forcing a version records it without running anything.
+	if err := m.Force(ctx, latest+1); err == nil {
+		t.Error("forced an unknown version")
+	}
+	if err := m.Force(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	// The tables are there but untracked, so up refuses to run.
+	if err := m.Up(ctx); err == nil || !strings.Contains(err.Error(), "the deals table exists") {
+		t.Errorf("up over untracked tables: %v", err)
+	}
+	if err := m.Force(ctx, 11); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if v := version(); v != latest {
+		t.Errorf("at version %d, want %d", v, latest)
+	}
+}
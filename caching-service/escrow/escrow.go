@@ -0,0 +1,1258 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package escrow
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// BindingsMetaData contains all meta data concerning the Bindings contract.
+var BindingsMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_client\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_freelancer\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_arbiter\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_tokenAddress\",\"type\":\"address\"},{\"internalType\":\"uint256[]\",\"name\":\"_payouts\",\"type\":\"uint256[]\"},{\"internalType\":\"string[]\",\"name\":\"_detailsHashes\",\"type\":\"string[]\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"ReentrancyGuardReentrantCall\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"AgreementFunded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"milestoneId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"raisedBy\",\"type\":\"address\"}],\"name\":\"DisputeRaised\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"milestoneId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"winner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"DisputeResolved\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"milestoneId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"MilestoneApproved\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"milestoneId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"workHash\",\"type\":\"string\"}],\"name\":\"WorkSubmitted\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_milestoneId\",\"type\":\"uint256\"}],\"name\":\"approveMilestone\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"arbiter\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"client\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"currentStatus\",\"outputs\":[{\"internalType\":\"enumEscrow.AgreementStatus\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"freelancer\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"fundEscrow\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"milestones\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"payoutAmount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"detailsHash\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"workHash\",\"type\":\"string\"},{\"internalType\":\"enumEscrow.MilestoneStatus\",\"name\":\"state\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_milestoneId\",\"type\":\"uint256\"}],\"name\":\"raiseDispute\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_milestoneId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"_winner\",\"type\":\"address\"}],\"name\":\"resolveDispute\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_milestoneId\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"_workHash\",\"type\":\"string\"}],\"name\":\"submitWork\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"token\",\"outputs\":[{\"internalType\":\"contractIERC20\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalAmount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// BindingsABI is the input ABI used to generate the binding from.
+// Deprecated: Use BindingsMetaData.ABI instead.
+var BindingsABI = BindingsMetaData.ABI
+
+// Bindings is an auto generated Go binding around an Ethereum contract.
+type Bindings struct {
+	BindingsCaller     // Read-only binding to the contract
+	BindingsTransactor // Write-only binding to the contract
+	BindingsFilterer   // Log filterer for contract events
+}
+
+// BindingsCaller is an auto generated read-only Go binding around an Ethereum contract.
+type BindingsCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BindingsTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type BindingsTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BindingsFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type BindingsFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BindingsSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type BindingsSession struct {
+	Contract     *Bindings         // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BindingsCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type BindingsCallerSession struct {
+	Contract *BindingsCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts   // Call options to use throughout this session
+}
+
+// BindingsTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type BindingsTransactorSession struct {
+	Contract     *BindingsTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts   // Transaction auth options to use throughout this session
+}
+
+// BindingsRaw is an auto generated low-level Go binding around an Ethereum contract.
+type BindingsRaw struct {
+	Contract *Bindings // Generic contract binding to access the raw methods on
+}
+
+// BindingsCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type BindingsCallerRaw struct {
+	Contract *BindingsCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// BindingsTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type BindingsTransactorRaw struct {
+	Contract *BindingsTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBindings creates a new instance of Bindings, bound to a specific deployed contract.
+func NewBindings(address common.Address, backend bind.ContractBackend) (*Bindings, error) {
+	contract, err := bindBindings(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Bindings{BindingsCaller: BindingsCaller{contract: contract}, BindingsTransactor: BindingsTransactor{contract: contract}, BindingsFilterer: BindingsFilterer{contract: contract}}, nil
+}
+
+// NewBindingsCaller creates a new read-only instance of Bindings, bound to a specific deployed contract.
+func NewBindingsCaller(address common.Address, caller bind.ContractCaller) (*BindingsCaller, error) {
+	contract, err := bindBindings(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsCaller{contract: contract}, nil
+}
+
+// NewBindingsTransactor creates a new write-only instance of Bindings, bound to a specific deployed contract.
+func NewBindingsTransactor(address common.Address, transactor bind.ContractTransactor) (*BindingsTransactor, error) {
+	contract, err := bindBindings(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsTransactor{contract: contract}, nil
+}
+
+// NewBindingsFilterer creates a new log filterer instance of Bindings, bound to a specific deployed contract.
+func NewBindingsFilterer(address common.Address, filterer bind.ContractFilterer) (*BindingsFilterer, error) {
+	contract, err := bindBindings(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsFilterer{contract: contract}, nil
+}
+
+// bindBindings binds a generic wrapper to an already deployed contract.
+func bindBindings(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := BindingsMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bindings *BindingsRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bindings.Contract.BindingsCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bindings *BindingsRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bindings.Contract.BindingsTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bindings *BindingsRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bindings.Contract.BindingsTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bindings *BindingsCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bindings.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bindings *BindingsTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bindings.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bindings *BindingsTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bindings.Contract.contract.Transact(opts, method, params...)
+}
+
+// Arbiter is a free data retrieval call binding the contract method 0xfe25e00a.
+//
+// Solidity: function arbiter() view returns(address)
+func (_Bindings *BindingsCaller) Arbiter(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "arbiter")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Arbiter is a free data retrieval call binding the contract method 0xfe25e00a.
+//
+// Solidity: function arbiter() view returns(address)
+func (_Bindings *BindingsSession) Arbiter() (common.Address, error) {
+	return _Bindings.Contract.Arbiter(&_Bindings.CallOpts)
+}
+
+// Arbiter is a free data retrieval call binding the contract method 0xfe25e00a.
+//
+// Solidity: function arbiter() view returns(address)
+func (_Bindings *BindingsCallerSession) Arbiter() (common.Address, error) {
+	return _Bindings.Contract.Arbiter(&_Bindings.CallOpts)
+}
+
+// Client is a free data retrieval call binding the contract method 0x109e94cf.
+//
+// Solidity: function client() view returns(address)
+func (_Bindings *BindingsCaller) Client(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "client")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Client is a free data retrieval call binding the contract method 0x109e94cf.
+//
+// Solidity: function client() view returns(address)
+func (_Bindings *BindingsSession) Client() (common.Address, error) {
+	return _Bindings.Contract.Client(&_Bindings.CallOpts)
+}
+
+// Client is a free data retrieval call binding the contract method 0x109e94cf.
+//
+// Solidity: function client() view returns(address)
+func (_Bindings *BindingsCallerSession) Client() (common.Address, error) {
+	return _Bindings.Contract.Client(&_Bindings.CallOpts)
+}
+
+// CurrentStatus is a free data retrieval call binding the contract method 0xef8a9235.
+//
+// Solidity: function currentStatus() view returns(uint8)
+func (_Bindings *BindingsCaller) CurrentStatus(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "currentStatus")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// CurrentStatus is a free data retrieval call binding the contract method 0xef8a9235.
+//
+// Solidity: function currentStatus() view returns(uint8)
+func (_Bindings *BindingsSession) CurrentStatus() (uint8, error) {
+	return _Bindings.Contract.CurrentStatus(&_Bindings.CallOpts)
+}
+
+// CurrentStatus is a free data retrieval call binding the contract method 0xef8a9235.
+//
+// Solidity: function currentStatus() view returns(uint8)
+func (_Bindings *BindingsCallerSession) CurrentStatus() (uint8, error) {
+	return _Bindings.Contract.CurrentStatus(&_Bindings.CallOpts)
+}
+
+// Freelancer is a free data retrieval call binding the contract method 0xa37dda2c.
+//
+// Solidity: function freelancer() view returns(address)
+func (_Bindings *BindingsCaller) Freelancer(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "freelancer")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Freelancer is a free data retrieval call binding the contract method 0xa37dda2c.
+//
+// Solidity: function freelancer() view returns(address)
+func (_Bindings *BindingsSession) Freelancer() (common.Address, error) {
+	return _Bindings.Contract.Freelancer(&_Bindings.CallOpts)
+}
+
+// Freelancer is a free data retrieval call binding the contract method 0xa37dda2c.
+//
+// Solidity: function freelancer() view returns(address)
+func (_Bindings *BindingsCallerSession) Freelancer() (common.Address, error) {
+	return _Bindings.Contract.Freelancer(&_Bindings.CallOpts)
+}
+
+// Milestones is a free data retrieval call binding the contract method 0xe89e4ed6.
+//
+// Solidity: function milestones(uint256 ) view returns(uint256 payoutAmount, string detailsHash, string workHash, uint8 state)
+func (_Bindings *BindingsCaller) Milestones(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	PayoutAmount *big.Int
+	DetailsHash  string
+	WorkHash     string
+	State        uint8
+}, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "milestones", arg0)
+
+	outstruct := new(struct {
+		PayoutAmount *big.Int
+		DetailsHash  string
+		WorkHash     string
+		State        uint8
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.PayoutAmount = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.DetailsHash = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.WorkHash = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.State = *abi.ConvertType(out[3], new(uint8)).(*uint8)
+
+	return *outstruct, err
+
+}
+
+// Milestones is a free data retrieval call binding the contract method 0xe89e4ed6.
+//
+// Solidity: function milestones(uint256 ) view returns(uint256 payoutAmount, string detailsHash, string workHash, uint8 state)
+func (_Bindings *BindingsSession) Milestones(arg0 *big.Int) (struct {
+	PayoutAmount *big.Int
+	DetailsHash  string
+	WorkHash     string
+	State        uint8
+}, error) {
+	return _Bindings.Contract.Milestones(&_Bindings.CallOpts, arg0)
+}
+
+// Milestones is a free data retrieval call binding the contract method 0xe89e4ed6.
+//
+// Solidity: function milestones(uint256 ) view returns(uint256 payoutAmount, string detailsHash, string workHash, uint8 state)
+func (_Bindings *BindingsCallerSession) Milestones(arg0 *big.Int) (struct {
+	PayoutAmount *big.Int
+	DetailsHash  string
+	WorkHash     string
+	State        uint8
+}, error) {
+	return _Bindings.Contract.Milestones(&_Bindings.CallOpts, arg0)
+}
+
+// Token is a free data retrieval call binding the contract method 0xfc0c546a.
+//
+// Solidity: function token() view returns(address)
+func (_Bindings *BindingsCaller) Token(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "token")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Token is a free data retrieval call binding the contract method 0xfc0c546a.
+//
+// Solidity: function token() view returns(address)
+func (_Bindings *BindingsSession) Token() (common.Address, error) {
+	return _Bindings.Contract.Token(&_Bindings.CallOpts)
+}
+
+// Token is a free data retrieval call binding the contract method 0xfc0c546a.
+//
+// Solidity: function token() view returns(address)
+func (_Bindings *BindingsCallerSession) Token() (common.Address, error) {
+	return _Bindings.Contract.Token(&_Bindings.CallOpts)
+}
+
+// TotalAmount is a free data retrieval call binding the contract method 0x1a39d8ef.
+//
+// Solidity: function totalAmount() view returns(uint256)
+func (_Bindings *BindingsCaller) TotalAmount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "totalAmount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalAmount is a free data retrieval call binding the contract method 0x1a39d8ef.
+//
+// Solidity: function totalAmount() view returns(uint256)
+func (_Bindings *BindingsSession) TotalAmount() (*big.Int, error) {
+	return _Bindings.Contract.TotalAmount(&_Bindings.CallOpts)
+}
+
+// TotalAmount is a free data retrieval call binding the contract method 0x1a39d8ef.
+//
+// Solidity: function totalAmount() view returns(uint256)
+func (_Bindings *BindingsCallerSession) TotalAmount() (*big.Int, error) {
+	return _Bindings.Contract.TotalAmount(&_Bindings.CallOpts)
+}
+
+// ApproveMilestone is a paid mutator transaction binding the contract method 0xc438b40f.
+//
+// Solidity: function approveMilestone(uint256 _milestoneId) returns()
+func (_Bindings *BindingsTransactor) ApproveMilestone(opts *bind.TransactOpts, _milestoneId *big.Int) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "approveMilestone", _milestoneId)
+}
+
+// ApproveMilestone is a paid mutator transaction binding the contract method 0xc438b40f.
+//
+// Solidity: function approveMilestone(uint256 _milestoneId) returns()
+func (_Bindings *BindingsSession) ApproveMilestone(_milestoneId *big.Int) (*types.Transaction, error) {
+	return _Bindings.Contract.ApproveMilestone(&_Bindings.TransactOpts, _milestoneId)
+}
+
+// ApproveMilestone is a paid mutator transaction binding the contract method 0xc438b40f.
+//
+// Solidity: function approveMilestone(uint256 _milestoneId) returns()
+func (_Bindings *BindingsTransactorSession) ApproveMilestone(_milestoneId *big.Int) (*types.Transaction, error) {
+	return _Bindings.Contract.ApproveMilestone(&_Bindings.TransactOpts, _milestoneId)
+}
+
+// FundEscrow is a paid mutator transaction binding the contract method 0xa5d737ac.
+//
+// Solidity: function fundEscrow() returns()
+func (_Bindings *BindingsTransactor) FundEscrow(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "fundEscrow")
+}
+
+// FundEscrow is a paid mutator transaction binding the contract method 0xa5d737ac.
+//
+// Solidity: function fundEscrow() returns()
+func (_Bindings *BindingsSession) FundEscrow() (*types.Transaction, error) {
+	return _Bindings.Contract.FundEscrow(&_Bindings.TransactOpts)
+}
+
+// FundEscrow is a paid mutator transaction binding the contract method 0xa5d737ac.
+//
+// Solidity: function fundEscrow() returns()
+func (_Bindings *BindingsTransactorSession) FundEscrow() (*types.Transaction, error) {
+	return _Bindings.Contract.FundEscrow(&_Bindings.TransactOpts)
+}
+
+// RaiseDispute is a paid mutator transaction binding the contract method 0xa5c1674e.
+//
+// Solidity: function raiseDispute(uint256 _milestoneId) returns()
+func (_Bindings *BindingsTransactor) RaiseDispute(opts *bind.TransactOpts, _milestoneId *big.Int) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "raiseDispute", _milestoneId)
+}
+
+// RaiseDispute is a paid mutator transaction binding the contract method 0xa5c1674e.
+//
+// Solidity: function raiseDispute(uint256 _milestoneId) returns()
+func (_Bindings *BindingsSession) RaiseDispute(_milestoneId *big.Int) (*types.Transaction, error) {
+	return _Bindings.Contract.RaiseDispute(&_Bindings.TransactOpts, _milestoneId)
+}
+
+// RaiseDispute is a paid mutator transaction binding the contract method 0xa5c1674e.
+//
+// Solidity: function raiseDispute(uint256 _milestoneId) returns()
+func (_Bindings *BindingsTransactorSession) RaiseDispute(_milestoneId *big.Int) (*types.Transaction, error) {
+	return _Bindings.Contract.RaiseDispute(&_Bindings.TransactOpts, _milestoneId)
+}
+
+// ResolveDispute is a paid mutator transaction binding the contract method 0x0e33599d.
+//
+// Solidity: function resolveDispute(uint256 _milestoneId, address _winner) returns()
+func (_Bindings *BindingsTransactor) ResolveDispute(opts *bind.TransactOpts, _milestoneId *big.Int, _winner common.Address) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "resolveDispute", _milestoneId, _winner)
+}
+
+// ResolveDispute is a paid mutator transaction binding the contract method 0x0e33599d.
+//
+// Solidity: function resolveDispute(uint256 _milestoneId, address _winner) returns()
+func (_Bindings *BindingsSession) ResolveDispute(_milestoneId *big.Int, _winner common.Address) (*types.Transaction, error) {
+	return _Bindings.Contract.ResolveDispute(&_Bindings.TransactOpts, _milestoneId, _winner)
+}
+
+// ResolveDispute is a paid mutator transaction binding the contract method 0x0e33599d.
+//
+// Solidity: function resolveDispute(uint256 _milestoneId, address _winner) returns()
+func (_Bindings *BindingsTransactorSession) ResolveDispute(_milestoneId *big.Int, _winner common.Address) (*types.Transaction, error) {
+	return _Bindings.Contract.ResolveDispute(&_Bindings.TransactOpts, _milestoneId, _winner)
+}
+
+// SubmitWork is a paid mutator transaction binding the contract method 0xda8accf9.
+//
+// Solidity: function submitWork(uint256 _milestoneId, string _workHash) returns()
+func (_Bindings *BindingsTransactor) SubmitWork(opts *bind.TransactOpts, _milestoneId *big.Int, _workHash string) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "submitWork", _milestoneId, _workHash)
+}
+
+// SubmitWork is a paid mutator transaction binding the contract method 0xda8accf9.
+//
+// Solidity: function submitWork(uint256 _milestoneId, string _workHash) returns()
+func (_Bindings *BindingsSession) SubmitWork(_milestoneId *big.Int, _workHash string) (*types.Transaction, error) {
+	return _Bindings.Contract.SubmitWork(&_Bindings.TransactOpts, _milestoneId, _workHash)
+}
+
+// SubmitWork is a paid mutator transaction binding the contract method 0xda8accf9.
+//
+// Solidity: function submitWork(uint256 _milestoneId, string _workHash) returns()
+func (_Bindings *BindingsTransactorSession) SubmitWork(_milestoneId *big.Int, _workHash string) (*types.Transaction, error) {
+	return _Bindings.Contract.SubmitWork(&_Bindings.TransactOpts, _milestoneId, _workHash)
+}
+
+// BindingsAgreementFundedIterator is returned from FilterAgreementFunded and is used to iterate over the raw logs and unpacked data for AgreementFunded events raised by the Bindings contract.
+type BindingsAgreementFundedIterator struct {
+	Event *BindingsAgreementFunded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BindingsAgreementFundedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BindingsAgreementFunded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BindingsAgreementFunded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BindingsAgreementFundedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BindingsAgreementFundedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BindingsAgreementFunded represents a AgreementFunded event raised by the Bindings contract.
+type BindingsAgreementFunded struct {
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterAgreementFunded is a free log retrieval operation binding the contract event 0xdedbe31fcfd8842a2ccb1ab927ec0ccbd3ea30eda90fa7bc43b1d3a511963cb2.
+//
+// Solidity: event AgreementFunded(uint256 amount)
+func (_Bindings *BindingsFilterer) FilterAgreementFunded(opts *bind.FilterOpts) (*BindingsAgreementFundedIterator, error) {
+
+	logs, sub, err := _Bindings.contract.FilterLogs(opts, "AgreementFunded")
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsAgreementFundedIterator{contract: _Bindings.contract, event: "AgreementFunded", logs: logs, sub: sub}, nil
+}
+
+// WatchAgreementFunded is a free log subscription operation binding the contract event 0xdedbe31fcfd8842a2ccb1ab927ec0ccbd3ea30eda90fa7bc43b1d3a511963cb2.
+//
+// Solidity: event AgreementFunded(uint256 amount)
+func (_Bindings *BindingsFilterer) WatchAgreementFunded(opts *bind.WatchOpts, sink chan<- *BindingsAgreementFunded) (event.Subscription, error) {
+
+	logs, sub, err := _Bindings.contract.WatchLogs(opts, "AgreementFunded")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BindingsAgreementFunded)
+				if err := _Bindings.contract.UnpackLog(event, "AgreementFunded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAgreementFunded is a log parse operation binding the contract event 0xdedbe31fcfd8842a2ccb1ab927ec0ccbd3ea30eda90fa7bc43b1d3a511963cb2.
+//
+// Solidity: event AgreementFunded(uint256 amount)
+func (_Bindings *BindingsFilterer) ParseAgreementFunded(log types.Log) (*BindingsAgreementFunded, error) {
+	event := new(BindingsAgreementFunded)
+	if err := _Bindings.contract.UnpackLog(event, "AgreementFunded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BindingsDisputeRaisedIterator is returned from FilterDisputeRaised and is used to iterate over the raw logs and unpacked data for DisputeRaised events raised by the Bindings contract.
+type BindingsDisputeRaisedIterator struct {
+	Event *BindingsDisputeRaised // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BindingsDisputeRaisedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BindingsDisputeRaised)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BindingsDisputeRaised)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BindingsDisputeRaisedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BindingsDisputeRaisedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BindingsDisputeRaised represents a DisputeRaised event raised by the Bindings contract.
+type BindingsDisputeRaised struct {
+	MilestoneId *big.Int
+	RaisedBy    common.Address
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterDisputeRaised is a free log retrieval operation binding the contract event 0x84a477df8a28a4276ca6dee4458a06c3015f30c477d9c949ede4e13ff8a552b4.
+//
+// Solidity: event DisputeRaised(uint256 indexed milestoneId, address indexed raisedBy)
+func (_Bindings *BindingsFilterer) FilterDisputeRaised(opts *bind.FilterOpts, milestoneId []*big.Int, raisedBy []common.Address) (*BindingsDisputeRaisedIterator, error) {
+
+	var milestoneIdRule []interface{}
+	for _, milestoneIdItem := range milestoneId {
+		milestoneIdRule = append(milestoneIdRule, milestoneIdItem)
+	}
+	var raisedByRule []interface{}
+	for _, raisedByItem := range raisedBy {
+		raisedByRule = append(raisedByRule, raisedByItem)
+	}
+
+	logs, sub, err := _Bindings.contract.FilterLogs(opts, "DisputeRaised", milestoneIdRule, raisedByRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsDisputeRaisedIterator{contract: _Bindings.contract, event: "DisputeRaised", logs: logs, sub: sub}, nil
+}
+
+// WatchDisputeRaised is a free log subscription operation binding the contract event 0x84a477df8a28a4276ca6dee4458a06c3015f30c477d9c949ede4e13ff8a552b4.
+//
+// Solidity: event DisputeRaised(uint256 indexed milestoneId, address indexed raisedBy)
+func (_Bindings *BindingsFilterer) WatchDisputeRaised(opts *bind.WatchOpts, sink chan<- *BindingsDisputeRaised, milestoneId []*big.Int, raisedBy []common.Address) (event.Subscription, error) {
+
+	var milestoneIdRule []interface{}
+	for _, milestoneIdItem := range milestoneId {
+		milestoneIdRule = append(milestoneIdRule, milestoneIdItem)
+	}
+	var raisedByRule []interface{}
+	for _, raisedByItem := range raisedBy {
+		raisedByRule = append(raisedByRule, raisedByItem)
+	}
+
+	logs, sub, err := _Bindings.contract.WatchLogs(opts, "DisputeRaised", milestoneIdRule, raisedByRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BindingsDisputeRaised)
+				if err := _Bindings.contract.UnpackLog(event, "DisputeRaised", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDisputeRaised is a log parse operation binding the contract event 0x84a477df8a28a4276ca6dee4458a06c3015f30c477d9c949ede4e13ff8a552b4.
+//
+// Solidity: event DisputeRaised(uint256 indexed milestoneId, address indexed raisedBy)
+func (_Bindings *BindingsFilterer) ParseDisputeRaised(log types.Log) (*BindingsDisputeRaised, error) {
+	event := new(BindingsDisputeRaised)
+	if err := _Bindings.contract.UnpackLog(event, "DisputeRaised", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BindingsDisputeResolvedIterator is returned from FilterDisputeResolved and is used to iterate over the raw logs and unpacked data for DisputeResolved events raised by the Bindings contract.
+type BindingsDisputeResolvedIterator struct {
+	Event *BindingsDisputeResolved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BindingsDisputeResolvedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BindingsDisputeResolved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BindingsDisputeResolved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BindingsDisputeResolvedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BindingsDisputeResolvedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BindingsDisputeResolved represents a DisputeResolved event raised by the Bindings contract.
+type BindingsDisputeResolved struct {
+	MilestoneId *big.Int
+	Winner      common.Address
+	Amount      *big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterDisputeResolved is a free log retrieval operation binding the contract event 0xdf0baf5489595ea17a097d16827d60793e2f57f3b85a982e5d7e02838b841ee6.
+//
+// Solidity: event DisputeResolved(uint256 indexed milestoneId, address indexed winner, uint256 amount)
+func (_Bindings *BindingsFilterer) FilterDisputeResolved(opts *bind.FilterOpts, milestoneId []*big.Int, winner []common.Address) (*BindingsDisputeResolvedIterator, error) {
+
+	var milestoneIdRule []interface{}
+	for _, milestoneIdItem := range milestoneId {
+		milestoneIdRule = append(milestoneIdRule, milestoneIdItem)
+	}
+	var winnerRule []interface{}
+	for _, winnerItem := range winner {
+		winnerRule = append(winnerRule, winnerItem)
+	}
+
+	logs, sub, err := _Bindings.contract.FilterLogs(opts, "DisputeResolved", milestoneIdRule, winnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsDisputeResolvedIterator{contract: _Bindings.contract, event: "DisputeResolved", logs: logs, sub: sub}, nil
+}
+
+// WatchDisputeResolved is a free log subscription operation binding the contract event 0xdf0baf5489595ea17a097d16827d60793e2f57f3b85a982e5d7e02838b841ee6.
+//
+// Solidity: event DisputeResolved(uint256 indexed milestoneId, address indexed winner, uint256 amount)
+func (_Bindings *BindingsFilterer) WatchDisputeResolved(opts *bind.WatchOpts, sink chan<- *BindingsDisputeResolved, milestoneId []*big.Int, winner []common.Address) (event.Subscription, error) {
+
+	var milestoneIdRule []interface{}
+	for _, milestoneIdItem := range milestoneId {
+		milestoneIdRule = append(milestoneIdRule, milestoneIdItem)
+	}
+	var winnerRule []interface{}
+	for _, winnerItem := range winner {
+		winnerRule = append(winnerRule, winnerItem)
+	}
+
+	logs, sub, err := _Bindings.contract.WatchLogs(opts, "DisputeResolved", milestoneIdRule, winnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BindingsDisputeResolved)
+				if err := _Bindings.contract.UnpackLog(event, "DisputeResolved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDisputeResolved is a log parse operation binding the contract event 0xdf0baf5489595ea17a097d16827d60793e2f57f3b85a982e5d7e02838b841ee6.
+//
+// Solidity: event DisputeResolved(uint256 indexed milestoneId, address indexed winner, uint256 amount)
+func (_Bindings *BindingsFilterer) ParseDisputeResolved(log types.Log) (*BindingsDisputeResolved, error) {
+	event := new(BindingsDisputeResolved)
+	if err := _Bindings.contract.UnpackLog(event, "DisputeResolved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BindingsMilestoneApprovedIterator is returned from FilterMilestoneApproved and is used to iterate over the raw logs and unpacked data for MilestoneApproved events raised by the Bindings contract.
+type BindingsMilestoneApprovedIterator struct {
+	Event *BindingsMilestoneApproved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BindingsMilestoneApprovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BindingsMilestoneApproved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BindingsMilestoneApproved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BindingsMilestoneApprovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BindingsMilestoneApprovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BindingsMilestoneApproved represents a MilestoneApproved event raised by the Bindings contract.
+type BindingsMilestoneApproved struct {
+	MilestoneId *big.Int
+	Amount      *big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterMilestoneApproved is a free log retrieval operation binding the contract event 0x939da3b627c123c81fe5aacebf925163337a0d4f8a03724640618078cad24894.
+//
+// Solidity: event MilestoneApproved(uint256 indexed milestoneId, uint256 amount)
+func (_Bindings *BindingsFilterer) FilterMilestoneApproved(opts *bind.FilterOpts, milestoneId []*big.Int) (*BindingsMilestoneApprovedIterator, error) {
+
+	var milestoneIdRule []interface{}
+	for _, milestoneIdItem := range milestoneId {
+		milestoneIdRule = append(milestoneIdRule, milestoneIdItem)
+	}
+
+	logs, sub, err := _Bindings.contract.FilterLogs(opts, "MilestoneApproved", milestoneIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsMilestoneApprovedIterator{contract: _Bindings.contract, event: "MilestoneApproved", logs: logs, sub: sub}, nil
+}
+
+// WatchMilestoneApproved is a free log subscription operation binding the contract event 0x939da3b627c123c81fe5aacebf925163337a0d4f8a03724640618078cad24894.
+//
+// Solidity: event MilestoneApproved(uint256 indexed milestoneId, uint256 amount)
+func (_Bindings *BindingsFilterer) WatchMilestoneApproved(opts *bind.WatchOpts, sink chan<- *BindingsMilestoneApproved, milestoneId []*big.Int) (event.Subscription, error) {
+
+	var milestoneIdRule []interface{}
+	for _, milestoneIdItem := range milestoneId {
+		milestoneIdRule = append(milestoneIdRule, milestoneIdItem)
+	}
+
+	logs, sub, err := _Bindings.contract.WatchLogs(opts, "MilestoneApproved", milestoneIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BindingsMilestoneApproved)
+				if err := _Bindings.contract.UnpackLog(event, "MilestoneApproved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMilestoneApproved is a log parse operation binding the contract event 0x939da3b627c123c81fe5aacebf925163337a0d4f8a03724640618078cad24894.
+//
+// Solidity: event MilestoneApproved(uint256 indexed milestoneId, uint256 amount)
+func (_Bindings *BindingsFilterer) ParseMilestoneApproved(log types.Log) (*BindingsMilestoneApproved, error) {
+	event := new(BindingsMilestoneApproved)
+	if err := _Bindings.contract.UnpackLog(event, "MilestoneApproved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BindingsWorkSubmittedIterator is returned from FilterWorkSubmitted and is used to iterate over the raw logs and unpacked data for WorkSubmitted events raised by the Bindings contract.
+type BindingsWorkSubmittedIterator struct {
+	Event *BindingsWorkSubmitted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BindingsWorkSubmittedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BindingsWorkSubmitted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BindingsWorkSubmitted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BindingsWorkSubmittedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BindingsWorkSubmittedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BindingsWorkSubmitted represents a WorkSubmitted event raised by the Bindings contract.
+type BindingsWorkSubmitted struct {
+	MilestoneId *big.Int
+	WorkHash    string
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterWorkSubmitted is a free log retrieval operation binding the contract event 0x3822c71b106c7c1dabf7f076e68c9bd98c45d0cd0d073f4dcdaf422a0fe55847.
+//
+// Solidity: event WorkSubmitted(uint256 indexed milestoneId, string workHash)
+func (_Bindings *BindingsFilterer) FilterWorkSubmitted(opts *bind.FilterOpts, milestoneId []*big.Int) (*BindingsWorkSubmittedIterator, error) {
+
+	var milestoneIdRule []interface{}
+	for _, milestoneIdItem := range milestoneId {
+		milestoneIdRule = append(milestoneIdRule, milestoneIdItem)
+	}
+
+	logs, sub, err := _Bindings.contract.FilterLogs(opts, "WorkSubmitted", milestoneIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsWorkSubmittedIterator{contract: _Bindings.contract, event: "WorkSubmitted", logs: logs, sub: sub}, nil
+}
+
+// WatchWorkSubmitted is a free log subscription operation binding the contract event 0x3822c71b106c7c1dabf7f076e68c9bd98c45d0cd0d073f4dcdaf422a0fe55847.
+//
+// Solidity: event WorkSubmitted(uint256 indexed milestoneId, string workHash)
+func (_Bindings *BindingsFilterer) WatchWorkSubmitted(opts *bind.WatchOpts, sink chan<- *BindingsWorkSubmitted, milestoneId []*big.Int) (event.Subscription, error) {
+
+	var milestoneIdRule []interface{}
+	for _, milestoneIdItem := range milestoneId {
+		milestoneIdRule = append(milestoneIdRule, milestoneIdItem)
+	}
+
+	logs, sub, err := _Bindings.contract.WatchLogs(opts, "WorkSubmitted", milestoneIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BindingsWorkSubmitted)
+				if err := _Bindings.contract.UnpackLog(event, "WorkSubmitted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWorkSubmitted is a log parse operation binding the contract event 0x3822c71b106c7c1dabf7f076e68c9bd98c45d0cd0d073f4dcdaf422a0fe55847.
+//
+// Solidity: event WorkSubmitted(uint256 indexed milestoneId, string workHash)
+func (_Bindings *BindingsFilterer) ParseWorkSubmitted(log types.Log) (*BindingsWorkSubmitted, error) {
+	event := new(BindingsWorkSubmitted)
+	if err := _Bindings.contract.UnpackLog(event, "WorkSubmitted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,18 @@
+// Code generated by cmd/bindgen - DO NOT EDIT.
+
+package escrowfactory
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/bindcheck"
+)
+
+// Artifact describes the Hardhat artifact this binding was generated from.
+var Artifact = bindcheck.Artifact{
+	Contract:             "contracts/EscrowFactory.sol:EscrowFactory",
+	DeployedBytecodeHash: common.HexToHash("0x0f98d057da6494b47e195678a20d2173ba152b2a923469085bef2c1fa3d69821"),
+	ImmutableRanges: []bindcheck.Range{
+		{Start: 126, Length: 32},
+		{Start: 1045, Length: 32},
+	},
+}
@@ -31,7 +31,7 @@ var (
 
 // BindingsMetaData contains all meta data concerning the Bindings contract.
 var BindingsMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_arbiterRegistryAddress\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"escrowAddress\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"client\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"freelancer\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"totalAmount\",\"type\":\"uint256\"}],\"name\":\"EscrowCreated\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"arbiterRegistry\",\"outputs\":[{\"internalType\":\"contractArbiterRegistry\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_freelancer\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_arbiter\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_tokenAddress\",\"type\":\"address\"},{\"internalType\":\"uint256[]\",\"name\":\"_payouts\",\"type\":\"uint256[]\"},{\"internalType\":\"string[]\",\"name\":\"_detailsHashes\",\"type\":\"string[]\"}],\"name\":\"createEscrow\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"escrowContracts\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getEscrowContracts\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_arbiterRegistryAddress\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"escrowAddress\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"client\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"freelancer\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"totalAmount\",\"type\":\"uint256\"}],\"name\":\"EscrowCreated\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"arbiterRegistry\",\"outputs\":[{\"internalType\":\"contractArbiterRegistry\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_freelancer\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_arbiter\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_tokenAddress\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"_projectDescription\",\"type\":\"string\"}],\"name\":\"createEscrow\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"escrowContracts\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getEscrowContracts\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getEscrowCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"}],\"name\":\"getUserEscrows\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
 }
 
 // BindingsABI is the input ABI used to generate the binding from.
@@ -273,25 +273,87 @@ func (_Bindings *BindingsCallerSession) GetEscrowContracts() ([]common.Address,
 	return _Bindings.Contract.GetEscrowContracts(&_Bindings.CallOpts)
 }
 
-// CreateEscrow is a paid mutator transaction binding the contract method 0x0ab51761.
+// GetEscrowCount is a free data retrieval call binding the contract method 0x16b15135.
 //
-// Solidity: function createEscrow(address _freelancer, address _arbiter, address _tokenAddress, uint256[] _payouts, string[] _detailsHashes) returns()
-func (_Bindings *BindingsTransactor) CreateEscrow(opts *bind.TransactOpts, _freelancer common.Address, _arbiter common.Address, _tokenAddress common.Address, _payouts []*big.Int, _detailsHashes []string) (*types.Transaction, error) {
-	return _Bindings.contract.Transact(opts, "createEscrow", _freelancer, _arbiter, _tokenAddress, _payouts, _detailsHashes)
+// Solidity: function getEscrowCount() view returns(uint256)
+func (_Bindings *BindingsCaller) GetEscrowCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "getEscrowCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetEscrowCount is a free data retrieval call binding the contract method 0x16b15135.
+//
+// Solidity: function getEscrowCount() view returns(uint256)
+func (_Bindings *BindingsSession) GetEscrowCount() (*big.Int, error) {
+	return _Bindings.Contract.GetEscrowCount(&_Bindings.CallOpts)
+}
+
+// GetEscrowCount is a free data retrieval call binding the contract method 0x16b15135.
+//
+// Solidity: function getEscrowCount() view returns(uint256)
+func (_Bindings *BindingsCallerSession) GetEscrowCount() (*big.Int, error) {
+	return _Bindings.Contract.GetEscrowCount(&_Bindings.CallOpts)
+}
+
+// GetUserEscrows is a free data retrieval call binding the contract method 0x5ccea85e.
+//
+// Solidity: function getUserEscrows(address user) view returns(address[])
+func (_Bindings *BindingsCaller) GetUserEscrows(opts *bind.CallOpts, user common.Address) ([]common.Address, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "getUserEscrows", user)
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetUserEscrows is a free data retrieval call binding the contract method 0x5ccea85e.
+//
+// Solidity: function getUserEscrows(address user) view returns(address[])
+func (_Bindings *BindingsSession) GetUserEscrows(user common.Address) ([]common.Address, error) {
+	return _Bindings.Contract.GetUserEscrows(&_Bindings.CallOpts, user)
+}
+
+// GetUserEscrows is a free data retrieval call binding the contract method 0x5ccea85e.
+//
+// Solidity: function getUserEscrows(address user) view returns(address[])
+func (_Bindings *BindingsCallerSession) GetUserEscrows(user common.Address) ([]common.Address, error) {
+	return _Bindings.Contract.GetUserEscrows(&_Bindings.CallOpts, user)
+}
+
+// CreateEscrow is a paid mutator transaction binding the contract method 0x7c61759b.
+//
+// Solidity: function createEscrow(address _freelancer, address _arbiter, address _tokenAddress, uint256 _amount, string _projectDescription) returns()
+func (_Bindings *BindingsTransactor) CreateEscrow(opts *bind.TransactOpts, _freelancer common.Address, _arbiter common.Address, _tokenAddress common.Address, _amount *big.Int, _projectDescription string) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "createEscrow", _freelancer, _arbiter, _tokenAddress, _amount, _projectDescription)
 }
 
-// CreateEscrow is a paid mutator transaction binding the contract method 0x0ab51761.
+// CreateEscrow is a paid mutator transaction binding the contract method 0x7c61759b.
 //
-// Solidity: function createEscrow(address _freelancer, address _arbiter, address _tokenAddress, uint256[] _payouts, string[] _detailsHashes) returns()
-func (_Bindings *BindingsSession) CreateEscrow(_freelancer common.Address, _arbiter common.Address, _tokenAddress common.Address, _payouts []*big.Int, _detailsHashes []string) (*types.Transaction, error) {
-	return _Bindings.Contract.CreateEscrow(&_Bindings.TransactOpts, _freelancer, _arbiter, _tokenAddress, _payouts, _detailsHashes)
+// Solidity: function createEscrow(address _freelancer, address _arbiter, address _tokenAddress, uint256 _amount, string _projectDescription) returns()
+func (_Bindings *BindingsSession) CreateEscrow(_freelancer common.Address, _arbiter common.Address, _tokenAddress common.Address, _amount *big.Int, _projectDescription string) (*types.Transaction, error) {
+	return _Bindings.Contract.CreateEscrow(&_Bindings.TransactOpts, _freelancer, _arbiter, _tokenAddress, _amount, _projectDescription)
 }
 
-// CreateEscrow is a paid mutator transaction binding the contract method 0x0ab51761.
+// CreateEscrow is a paid mutator transaction binding the contract method 0x7c61759b.
 //
-// Solidity: function createEscrow(address _freelancer, address _arbiter, address _tokenAddress, uint256[] _payouts, string[] _detailsHashes) returns()
-func (_Bindings *BindingsTransactorSession) CreateEscrow(_freelancer common.Address, _arbiter common.Address, _tokenAddress common.Address, _payouts []*big.Int, _detailsHashes []string) (*types.Transaction, error) {
-	return _Bindings.Contract.CreateEscrow(&_Bindings.TransactOpts, _freelancer, _arbiter, _tokenAddress, _payouts, _detailsHashes)
+// Solidity: function createEscrow(address _freelancer, address _arbiter, address _tokenAddress, uint256 _amount, string _projectDescription) returns()
+func (_Bindings *BindingsTransactorSession) CreateEscrow(_freelancer common.Address, _arbiter common.Address, _tokenAddress common.Address, _amount *big.Int, _projectDescription string) (*types.Transaction, error) {
+	return _Bindings.Contract.CreateEscrow(&_Bindings.TransactOpts, _freelancer, _arbiter, _tokenAddress, _amount, _projectDescription)
 }
 
 // BindingsEscrowCreatedIterator is returned from FilterEscrowCreated and is used to iterate over the raw logs and unpacked data for EscrowCreated events raised by the Bindings contract.
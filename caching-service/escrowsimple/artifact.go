@@ -0,0 +1,15 @@
+// Code generated by cmd/bindgen - DO NOT EDIT.
+
+package escrowsimple
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/bindcheck"
+)
+
+// Artifact describes the Hardhat artifact this binding was generated from.
+var Artifact = bindcheck.Artifact{
+	Contract:             "contracts/EscrowSimple.sol:EscrowSimple",
+	DeployedBytecodeHash: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000000"),
+	ImmutableRanges:      []bindcheck.Range{},
+}
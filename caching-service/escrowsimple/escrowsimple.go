@@ -0,0 +1,1331 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package escrowsimple
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// BindingsMetaData contains all meta data concerning the Bindings contract.
+var BindingsMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_client\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_freelancer\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_arbiter\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_tokenAddress\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"_projectDescription\",\"type\":\"string\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"ReentrancyGuardReentrantCall\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"AgreementFunded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"raisedBy\",\"type\":\"address\"}],\"name\":\"DisputeRaised\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"winner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"DisputeResolved\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"WorkApproved\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"workSubmission\",\"type\":\"string\"}],\"name\":\"WorkSubmitted\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"approveWork\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"arbiter\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"client\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"currentStatus\",\"outputs\":[{\"internalType\":\"enumEscrowSimple.AgreementStatus\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"freelancer\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"fundEscrow\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getProjectDetails\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"_client\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_freelancer\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_arbiter\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_totalAmount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"_projectDescription\",\"type\":\"string\"},{\"internalType\":\"enumEscrowSimple.AgreementStatus\",\"name\":\"_currentStatus\",\"type\":\"uint8\"},{\"internalType\":\"enumEscrowSimple.WorkStatus\",\"name\":\"_workStatus\",\"type\":\"uint8\"},{\"internalType\":\"string\",\"name\":\"_workSubmission\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"projectDescription\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"raiseDispute\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_winner\",\"type\":\"address\"}],\"name\":\"resolveDispute\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_workSubmission\",\"type\":\"string\"}],\"name\":\"submitWork\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"token\",\"outputs\":[{\"internalType\":\"contractIERC20\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalAmount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"workStatus\",\"outputs\":[{\"internalType\":\"enumEscrowSimple.WorkStatus\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"workSubmission\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// BindingsABI is the input ABI used to generate the binding from.
+// Deprecated: Use BindingsMetaData.ABI instead.
+var BindingsABI = BindingsMetaData.ABI
+
+// Bindings is an auto generated Go binding around an Ethereum contract.
+type Bindings struct {
+	BindingsCaller     // Read-only binding to the contract
+	BindingsTransactor // Write-only binding to the contract
+	BindingsFilterer   // Log filterer for contract events
+}
+
+// BindingsCaller is an auto generated read-only Go binding around an Ethereum contract.
+type BindingsCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BindingsTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type BindingsTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BindingsFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type BindingsFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BindingsSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type BindingsSession struct {
+	Contract     *Bindings         // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BindingsCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type BindingsCallerSession struct {
+	Contract *BindingsCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts   // Call options to use throughout this session
+}
+
+// BindingsTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type BindingsTransactorSession struct {
+	Contract     *BindingsTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts   // Transaction auth options to use throughout this session
+}
+
+// BindingsRaw is an auto generated low-level Go binding around an Ethereum contract.
+type BindingsRaw struct {
+	Contract *Bindings // Generic contract binding to access the raw methods on
+}
+
+// BindingsCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type BindingsCallerRaw struct {
+	Contract *BindingsCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// BindingsTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type BindingsTransactorRaw struct {
+	Contract *BindingsTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBindings creates a new instance of Bindings, bound to a specific deployed contract.
+func NewBindings(address common.Address, backend bind.ContractBackend) (*Bindings, error) {
+	contract, err := bindBindings(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Bindings{BindingsCaller: BindingsCaller{contract: contract}, BindingsTransactor: BindingsTransactor{contract: contract}, BindingsFilterer: BindingsFilterer{contract: contract}}, nil
+}
+
+// NewBindingsCaller creates a new read-only instance of Bindings, bound to a specific deployed contract.
+func NewBindingsCaller(address common.Address, caller bind.ContractCaller) (*BindingsCaller, error) {
+	contract, err := bindBindings(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsCaller{contract: contract}, nil
+}
+
+// NewBindingsTransactor creates a new write-only instance of Bindings, bound to a specific deployed contract.
+func NewBindingsTransactor(address common.Address, transactor bind.ContractTransactor) (*BindingsTransactor, error) {
+	contract, err := bindBindings(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsTransactor{contract: contract}, nil
+}
+
+// NewBindingsFilterer creates a new log filterer instance of Bindings, bound to a specific deployed contract.
+func NewBindingsFilterer(address common.Address, filterer bind.ContractFilterer) (*BindingsFilterer, error) {
+	contract, err := bindBindings(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsFilterer{contract: contract}, nil
+}
+
+// bindBindings binds a generic wrapper to an already deployed contract.
+func bindBindings(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := BindingsMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bindings *BindingsRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bindings.Contract.BindingsCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bindings *BindingsRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bindings.Contract.BindingsTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bindings *BindingsRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bindings.Contract.BindingsTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bindings *BindingsCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bindings.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bindings *BindingsTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bindings.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bindings *BindingsTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bindings.Contract.contract.Transact(opts, method, params...)
+}
+
+// Arbiter is a free data retrieval call binding the contract method 0xfe25e00a.
+//
+// Solidity: function arbiter() view returns(address)
+func (_Bindings *BindingsCaller) Arbiter(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "arbiter")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Arbiter is a free data retrieval call binding the contract method 0xfe25e00a.
+//
+// Solidity: function arbiter() view returns(address)
+func (_Bindings *BindingsSession) Arbiter() (common.Address, error) {
+	return _Bindings.Contract.Arbiter(&_Bindings.CallOpts)
+}
+
+// Arbiter is a free data retrieval call binding the contract method 0xfe25e00a.
+//
+// Solidity: function arbiter() view returns(address)
+func (_Bindings *BindingsCallerSession) Arbiter() (common.Address, error) {
+	return _Bindings.Contract.Arbiter(&_Bindings.CallOpts)
+}
+
+// Client is a free data retrieval call binding the contract method 0x109e94cf.
+//
+// Solidity: function client() view returns(address)
+func (_Bindings *BindingsCaller) Client(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "client")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Client is a free data retrieval call binding the contract method 0x109e94cf.
+//
+// Solidity: function client() view returns(address)
+func (_Bindings *BindingsSession) Client() (common.Address, error) {
+	return _Bindings.Contract.Client(&_Bindings.CallOpts)
+}
+
+// Client is a free data retrieval call binding the contract method 0x109e94cf.
+//
+// Solidity: function client() view returns(address)
+func (_Bindings *BindingsCallerSession) Client() (common.Address, error) {
+	return _Bindings.Contract.Client(&_Bindings.CallOpts)
+}
+
+// CurrentStatus is a free data retrieval call binding the contract method 0xef8a9235.
+//
+// Solidity: function currentStatus() view returns(uint8)
+func (_Bindings *BindingsCaller) CurrentStatus(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "currentStatus")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// CurrentStatus is a free data retrieval call binding the contract method 0xef8a9235.
+//
+// Solidity: function currentStatus() view returns(uint8)
+func (_Bindings *BindingsSession) CurrentStatus() (uint8, error) {
+	return _Bindings.Contract.CurrentStatus(&_Bindings.CallOpts)
+}
+
+// CurrentStatus is a free data retrieval call binding the contract method 0xef8a9235.
+//
+// Solidity: function currentStatus() view returns(uint8)
+func (_Bindings *BindingsCallerSession) CurrentStatus() (uint8, error) {
+	return _Bindings.Contract.CurrentStatus(&_Bindings.CallOpts)
+}
+
+// Freelancer is a free data retrieval call binding the contract method 0xa37dda2c.
+//
+// Solidity: function freelancer() view returns(address)
+func (_Bindings *BindingsCaller) Freelancer(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "freelancer")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Freelancer is a free data retrieval call binding the contract method 0xa37dda2c.
+//
+// Solidity: function freelancer() view returns(address)
+func (_Bindings *BindingsSession) Freelancer() (common.Address, error) {
+	return _Bindings.Contract.Freelancer(&_Bindings.CallOpts)
+}
+
+// Freelancer is a free data retrieval call binding the contract method 0xa37dda2c.
+//
+// Solidity: function freelancer() view returns(address)
+func (_Bindings *BindingsCallerSession) Freelancer() (common.Address, error) {
+	return _Bindings.Contract.Freelancer(&_Bindings.CallOpts)
+}
+
+// GetProjectDetails is a free data retrieval call binding the contract method 0xff3a9c7e.
+//
+// Solidity: function getProjectDetails() view returns(address _client, address _freelancer, address _arbiter, uint256 _totalAmount, string _projectDescription, uint8 _currentStatus, uint8 _workStatus, string _workSubmission)
+func (_Bindings *BindingsCaller) GetProjectDetails(opts *bind.CallOpts) (struct {
+	Client             common.Address
+	Freelancer         common.Address
+	Arbiter            common.Address
+	TotalAmount        *big.Int
+	ProjectDescription string
+	CurrentStatus      uint8
+	WorkStatus         uint8
+	WorkSubmission     string
+}, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "getProjectDetails")
+
+	outstruct := new(struct {
+		Client             common.Address
+		Freelancer         common.Address
+		Arbiter            common.Address
+		TotalAmount        *big.Int
+		ProjectDescription string
+		CurrentStatus      uint8
+		WorkStatus         uint8
+		WorkSubmission     string
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Client = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Freelancer = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.Arbiter = *abi.ConvertType(out[2], new(common.Address)).(*common.Address)
+	outstruct.TotalAmount = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.ProjectDescription = *abi.ConvertType(out[4], new(string)).(*string)
+	outstruct.CurrentStatus = *abi.ConvertType(out[5], new(uint8)).(*uint8)
+	outstruct.WorkStatus = *abi.ConvertType(out[6], new(uint8)).(*uint8)
+	outstruct.WorkSubmission = *abi.ConvertType(out[7], new(string)).(*string)
+
+	return *outstruct, err
+
+}
+
+// GetProjectDetails is a free data retrieval call binding the contract method 0xff3a9c7e.
+//
+// Solidity: function getProjectDetails() view returns(address _client, address _freelancer, address _arbiter, uint256 _totalAmount, string _projectDescription, uint8 _currentStatus, uint8 _workStatus, string _workSubmission)
+func (_Bindings *BindingsSession) GetProjectDetails() (struct {
+	Client             common.Address
+	Freelancer         common.Address
+	Arbiter            common.Address
+	TotalAmount        *big.Int
+	ProjectDescription string
+	CurrentStatus      uint8
+	WorkStatus         uint8
+	WorkSubmission     string
+}, error) {
+	return _Bindings.Contract.GetProjectDetails(&_Bindings.CallOpts)
+}
+
+// GetProjectDetails is a free data retrieval call binding the contract method 0xff3a9c7e.
+//
+// Solidity: function getProjectDetails() view returns(address _client, address _freelancer, address _arbiter, uint256 _totalAmount, string _projectDescription, uint8 _currentStatus, uint8 _workStatus, string _workSubmission)
+func (_Bindings *BindingsCallerSession) GetProjectDetails() (struct {
+	Client             common.Address
+	Freelancer         common.Address
+	Arbiter            common.Address
+	TotalAmount        *big.Int
+	ProjectDescription string
+	CurrentStatus      uint8
+	WorkStatus         uint8
+	WorkSubmission     string
+}, error) {
+	return _Bindings.Contract.GetProjectDetails(&_Bindings.CallOpts)
+}
+
+// ProjectDescription is a free data retrieval call binding the contract method 0x755f047b.
+//
+// Solidity: function projectDescription() view returns(string)
+func (_Bindings *BindingsCaller) ProjectDescription(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "projectDescription")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// ProjectDescription is a free data retrieval call binding the contract method 0x755f047b.
+//
+// Solidity: function projectDescription() view returns(string)
+func (_Bindings *BindingsSession) ProjectDescription() (string, error) {
+	return _Bindings.Contract.ProjectDescription(&_Bindings.CallOpts)
+}
+
+// ProjectDescription is a free data retrieval call binding the contract method 0x755f047b.
+//
+// Solidity: function projectDescription() view returns(string)
+func (_Bindings *BindingsCallerSession) ProjectDescription() (string, error) {
+	return _Bindings.Contract.ProjectDescription(&_Bindings.CallOpts)
+}
+
+// Token is a free data retrieval call binding the contract method 0xfc0c546a.
+//
+// Solidity: function token() view returns(address)
+func (_Bindings *BindingsCaller) Token(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "token")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Token is a free data retrieval call binding the contract method 0xfc0c546a.
+//
+// Solidity: function token() view returns(address)
+func (_Bindings *BindingsSession) Token() (common.Address, error) {
+	return _Bindings.Contract.Token(&_Bindings.CallOpts)
+}
+
+// Token is a free data retrieval call binding the contract method 0xfc0c546a.
+//
+// Solidity: function token() view returns(address)
+func (_Bindings *BindingsCallerSession) Token() (common.Address, error) {
+	return _Bindings.Contract.Token(&_Bindings.CallOpts)
+}
+
+// TotalAmount is a free data retrieval call binding the contract method 0x1a39d8ef.
+//
+// Solidity: function totalAmount() view returns(uint256)
+func (_Bindings *BindingsCaller) TotalAmount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "totalAmount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalAmount is a free data retrieval call binding the contract method 0x1a39d8ef.
+//
+// Solidity: function totalAmount() view returns(uint256)
+func (_Bindings *BindingsSession) TotalAmount() (*big.Int, error) {
+	return _Bindings.Contract.TotalAmount(&_Bindings.CallOpts)
+}
+
+// TotalAmount is a free data retrieval call binding the contract method 0x1a39d8ef.
+//
+// Solidity: function totalAmount() view returns(uint256)
+func (_Bindings *BindingsCallerSession) TotalAmount() (*big.Int, error) {
+	return _Bindings.Contract.TotalAmount(&_Bindings.CallOpts)
+}
+
+// WorkStatus is a free data retrieval call binding the contract method 0xf06ffe64.
+//
+// Solidity: function workStatus() view returns(uint8)
+func (_Bindings *BindingsCaller) WorkStatus(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "workStatus")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// WorkStatus is a free data retrieval call binding the contract method 0xf06ffe64.
+//
+// Solidity: function workStatus() view returns(uint8)
+func (_Bindings *BindingsSession) WorkStatus() (uint8, error) {
+	return _Bindings.Contract.WorkStatus(&_Bindings.CallOpts)
+}
+
+// WorkStatus is a free data retrieval call binding the contract method 0xf06ffe64.
+//
+// Solidity: function workStatus() view returns(uint8)
+func (_Bindings *BindingsCallerSession) WorkStatus() (uint8, error) {
+	return _Bindings.Contract.WorkStatus(&_Bindings.CallOpts)
+}
+
+// WorkSubmission is a free data retrieval call binding the contract method 0x2bb30717.
+//
+// Solidity: function workSubmission() view returns(string)
+func (_Bindings *BindingsCaller) WorkSubmission(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "workSubmission")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// WorkSubmission is a free data retrieval call binding the contract method 0x2bb30717.
+//
+// Solidity: function workSubmission() view returns(string)
+func (_Bindings *BindingsSession) WorkSubmission() (string, error) {
+	return _Bindings.Contract.WorkSubmission(&_Bindings.CallOpts)
+}
+
+// WorkSubmission is a free data retrieval call binding the contract method 0x2bb30717.
+//
+// Solidity: function workSubmission() view returns(string)
+func (_Bindings *BindingsCallerSession) WorkSubmission() (string, error) {
+	return _Bindings.Contract.WorkSubmission(&_Bindings.CallOpts)
+}
+
+// ApproveWork is a paid mutator transaction binding the contract method 0xddb3a532.
+//
+// Solidity: function approveWork() returns()
+func (_Bindings *BindingsTransactor) ApproveWork(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "approveWork")
+}
+
+// ApproveWork is a paid mutator transaction binding the contract method 0xddb3a532.
+//
+// Solidity: function approveWork() returns()
+func (_Bindings *BindingsSession) ApproveWork() (*types.Transaction, error) {
+	return _Bindings.Contract.ApproveWork(&_Bindings.TransactOpts)
+}
+
+// ApproveWork is a paid mutator transaction binding the contract method 0xddb3a532.
+//
+// Solidity: function approveWork() returns()
+func (_Bindings *BindingsTransactorSession) ApproveWork() (*types.Transaction, error) {
+	return _Bindings.Contract.ApproveWork(&_Bindings.TransactOpts)
+}
+
+// FundEscrow is a paid mutator transaction binding the contract method 0xa5d737ac.
+//
+// Solidity: function fundEscrow() returns()
+func (_Bindings *BindingsTransactor) FundEscrow(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "fundEscrow")
+}
+
+// FundEscrow is a paid mutator transaction binding the contract method 0xa5d737ac.
+//
+// Solidity: function fundEscrow() returns()
+func (_Bindings *BindingsSession) FundEscrow() (*types.Transaction, error) {
+	return _Bindings.Contract.FundEscrow(&_Bindings.TransactOpts)
+}
+
+// FundEscrow is a paid mutator transaction binding the contract method 0xa5d737ac.
+//
+// Solidity: function fundEscrow() returns()
+func (_Bindings *BindingsTransactorSession) FundEscrow() (*types.Transaction, error) {
+	return _Bindings.Contract.FundEscrow(&_Bindings.TransactOpts)
+}
+
+// RaiseDispute is a paid mutator transaction binding the contract method 0x6daa2d44.
+//
+// Solidity: function raiseDispute() returns()
+func (_Bindings *BindingsTransactor) RaiseDispute(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "raiseDispute")
+}
+
+// RaiseDispute is a paid mutator transaction binding the contract method 0x6daa2d44.
+//
+// Solidity: function raiseDispute() returns()
+func (_Bindings *BindingsSession) RaiseDispute() (*types.Transaction, error) {
+	return _Bindings.Contract.RaiseDispute(&_Bindings.TransactOpts)
+}
+
+// RaiseDispute is a paid mutator transaction binding the contract method 0x6daa2d44.
+//
+// Solidity: function raiseDispute() returns()
+func (_Bindings *BindingsTransactorSession) RaiseDispute() (*types.Transaction, error) {
+	return _Bindings.Contract.RaiseDispute(&_Bindings.TransactOpts)
+}
+
+// ResolveDispute is a paid mutator transaction binding the contract method 0xd8461182.
+//
+// Solidity: function resolveDispute(address _winner) returns()
+func (_Bindings *BindingsTransactor) ResolveDispute(opts *bind.TransactOpts, _winner common.Address) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "resolveDispute", _winner)
+}
+
+// ResolveDispute is a paid mutator transaction binding the contract method 0xd8461182.
+//
+// Solidity: function resolveDispute(address _winner) returns()
+func (_Bindings *BindingsSession) ResolveDispute(_winner common.Address) (*types.Transaction, error) {
+	return _Bindings.Contract.ResolveDispute(&_Bindings.TransactOpts, _winner)
+}
+
+// ResolveDispute is a paid mutator transaction binding the contract method 0xd8461182.
+//
+// Solidity: function resolveDispute(address _winner) returns()
+func (_Bindings *BindingsTransactorSession) ResolveDispute(_winner common.Address) (*types.Transaction, error) {
+	return _Bindings.Contract.ResolveDispute(&_Bindings.TransactOpts, _winner)
+}
+
+// SubmitWork is a paid mutator transaction binding the contract method 0x095da7f6.
+//
+// Solidity: function submitWork(string _workSubmission) returns()
+func (_Bindings *BindingsTransactor) SubmitWork(opts *bind.TransactOpts, _workSubmission string) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "submitWork", _workSubmission)
+}
+
+// SubmitWork is a paid mutator transaction binding the contract method 0x095da7f6.
+//
+// Solidity: function submitWork(string _workSubmission) returns()
+func (_Bindings *BindingsSession) SubmitWork(_workSubmission string) (*types.Transaction, error) {
+	return _Bindings.Contract.SubmitWork(&_Bindings.TransactOpts, _workSubmission)
+}
+
+// SubmitWork is a paid mutator transaction binding the contract method 0x095da7f6.
+//
+// Solidity: function submitWork(string _workSubmission) returns()
+func (_Bindings *BindingsTransactorSession) SubmitWork(_workSubmission string) (*types.Transaction, error) {
+	return _Bindings.Contract.SubmitWork(&_Bindings.TransactOpts, _workSubmission)
+}
+
+// BindingsAgreementFundedIterator is returned from FilterAgreementFunded and is used to iterate over the raw logs and unpacked data for AgreementFunded events raised by the Bindings contract.
+type BindingsAgreementFundedIterator struct {
+	Event *BindingsAgreementFunded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BindingsAgreementFundedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BindingsAgreementFunded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BindingsAgreementFunded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BindingsAgreementFundedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BindingsAgreementFundedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BindingsAgreementFunded represents a AgreementFunded event raised by the Bindings contract.
+type BindingsAgreementFunded struct {
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterAgreementFunded is a free log retrieval operation binding the contract event 0xdedbe31fcfd8842a2ccb1ab927ec0ccbd3ea30eda90fa7bc43b1d3a511963cb2.
+//
+// Solidity: event AgreementFunded(uint256 amount)
+func (_Bindings *BindingsFilterer) FilterAgreementFunded(opts *bind.FilterOpts) (*BindingsAgreementFundedIterator, error) {
+
+	logs, sub, err := _Bindings.contract.FilterLogs(opts, "AgreementFunded")
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsAgreementFundedIterator{contract: _Bindings.contract, event: "AgreementFunded", logs: logs, sub: sub}, nil
+}
+
+// WatchAgreementFunded is a free log subscription operation binding the contract event 0xdedbe31fcfd8842a2ccb1ab927ec0ccbd3ea30eda90fa7bc43b1d3a511963cb2.
+//
+// Solidity: event AgreementFunded(uint256 amount)
+func (_Bindings *BindingsFilterer) WatchAgreementFunded(opts *bind.WatchOpts, sink chan<- *BindingsAgreementFunded) (event.Subscription, error) {
+
+	logs, sub, err := _Bindings.contract.WatchLogs(opts, "AgreementFunded")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BindingsAgreementFunded)
+				if err := _Bindings.contract.UnpackLog(event, "AgreementFunded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAgreementFunded is a log parse operation binding the contract event 0xdedbe31fcfd8842a2ccb1ab927ec0ccbd3ea30eda90fa7bc43b1d3a511963cb2.
+//
+// Solidity: event AgreementFunded(uint256 amount)
+func (_Bindings *BindingsFilterer) ParseAgreementFunded(log types.Log) (*BindingsAgreementFunded, error) {
+	event := new(BindingsAgreementFunded)
+	if err := _Bindings.contract.UnpackLog(event, "AgreementFunded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BindingsDisputeRaisedIterator is returned from FilterDisputeRaised and is used to iterate over the raw logs and unpacked data for DisputeRaised events raised by the Bindings contract.
+type BindingsDisputeRaisedIterator struct {
+	Event *BindingsDisputeRaised // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BindingsDisputeRaisedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BindingsDisputeRaised)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BindingsDisputeRaised)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BindingsDisputeRaisedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BindingsDisputeRaisedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BindingsDisputeRaised represents a DisputeRaised event raised by the Bindings contract.
+type BindingsDisputeRaised struct {
+	RaisedBy common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterDisputeRaised is a free log retrieval operation binding the contract event 0x0b3cd97b16a08a5c77357c276d248827ad32dfd56060f7c55d6255537ba3d962.
+//
+// Solidity: event DisputeRaised(address indexed raisedBy)
+func (_Bindings *BindingsFilterer) FilterDisputeRaised(opts *bind.FilterOpts, raisedBy []common.Address) (*BindingsDisputeRaisedIterator, error) {
+
+	var raisedByRule []interface{}
+	for _, raisedByItem := range raisedBy {
+		raisedByRule = append(raisedByRule, raisedByItem)
+	}
+
+	logs, sub, err := _Bindings.contract.FilterLogs(opts, "DisputeRaised", raisedByRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsDisputeRaisedIterator{contract: _Bindings.contract, event: "DisputeRaised", logs: logs, sub: sub}, nil
+}
+
+// WatchDisputeRaised is a free log subscription operation binding the contract event 0x0b3cd97b16a08a5c77357c276d248827ad32dfd56060f7c55d6255537ba3d962.
+//
+// Solidity: event DisputeRaised(address indexed raisedBy)
+func (_Bindings *BindingsFilterer) WatchDisputeRaised(opts *bind.WatchOpts, sink chan<- *BindingsDisputeRaised, raisedBy []common.Address) (event.Subscription, error) {
+
+	var raisedByRule []interface{}
+	for _, raisedByItem := range raisedBy {
+		raisedByRule = append(raisedByRule, raisedByItem)
+	}
+
+	logs, sub, err := _Bindings.contract.WatchLogs(opts, "DisputeRaised", raisedByRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BindingsDisputeRaised)
+				if err := _Bindings.contract.UnpackLog(event, "DisputeRaised", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDisputeRaised is a log parse operation binding the contract event 0x0b3cd97b16a08a5c77357c276d248827ad32dfd56060f7c55d6255537ba3d962.
+//
+// Solidity: event DisputeRaised(address indexed raisedBy)
+func (_Bindings *BindingsFilterer) ParseDisputeRaised(log types.Log) (*BindingsDisputeRaised, error) {
+	event := new(BindingsDisputeRaised)
+	if err := _Bindings.contract.UnpackLog(event, "DisputeRaised", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BindingsDisputeResolvedIterator is returned from FilterDisputeResolved and is used to iterate over the raw logs and unpacked data for DisputeResolved events raised by the Bindings contract.
+type BindingsDisputeResolvedIterator struct {
+	Event *BindingsDisputeResolved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BindingsDisputeResolvedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BindingsDisputeResolved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BindingsDisputeResolved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BindingsDisputeResolvedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BindingsDisputeResolvedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BindingsDisputeResolved represents a DisputeResolved event raised by the Bindings contract.
+type BindingsDisputeResolved struct {
+	Winner common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterDisputeResolved is a free log retrieval operation binding the contract event 0xf396531fad1d4dc2cd92386cdea800c92a507f8fe1bdb80313eb32a57481b1dc.
+//
+// Solidity: event DisputeResolved(address indexed winner, uint256 amount)
+func (_Bindings *BindingsFilterer) FilterDisputeResolved(opts *bind.FilterOpts, winner []common.Address) (*BindingsDisputeResolvedIterator, error) {
+
+	var winnerRule []interface{}
+	for _, winnerItem := range winner {
+		winnerRule = append(winnerRule, winnerItem)
+	}
+
+	logs, sub, err := _Bindings.contract.FilterLogs(opts, "DisputeResolved", winnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsDisputeResolvedIterator{contract: _Bindings.contract, event: "DisputeResolved", logs: logs, sub: sub}, nil
+}
+
+// WatchDisputeResolved is a free log subscription operation binding the contract event 0xf396531fad1d4dc2cd92386cdea800c92a507f8fe1bdb80313eb32a57481b1dc.
+//
+// Solidity: event DisputeResolved(address indexed winner, uint256 amount)
+func (_Bindings *BindingsFilterer) WatchDisputeResolved(opts *bind.WatchOpts, sink chan<- *BindingsDisputeResolved, winner []common.Address) (event.Subscription, error) {
+
+	var winnerRule []interface{}
+	for _, winnerItem := range winner {
+		winnerRule = append(winnerRule, winnerItem)
+	}
+
+	logs, sub, err := _Bindings.contract.WatchLogs(opts, "DisputeResolved", winnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BindingsDisputeResolved)
+				if err := _Bindings.contract.UnpackLog(event, "DisputeResolved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDisputeResolved is a log parse operation binding the contract event 0xf396531fad1d4dc2cd92386cdea800c92a507f8fe1bdb80313eb32a57481b1dc.
+//
+// Solidity: event DisputeResolved(address indexed winner, uint256 amount)
+func (_Bindings *BindingsFilterer) ParseDisputeResolved(log types.Log) (*BindingsDisputeResolved, error) {
+	event := new(BindingsDisputeResolved)
+	if err := _Bindings.contract.UnpackLog(event, "DisputeResolved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BindingsWorkApprovedIterator is returned from FilterWorkApproved and is used to iterate over the raw logs and unpacked data for WorkApproved events raised by the Bindings contract.
+type BindingsWorkApprovedIterator struct {
+	Event *BindingsWorkApproved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BindingsWorkApprovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BindingsWorkApproved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BindingsWorkApproved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BindingsWorkApprovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BindingsWorkApprovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BindingsWorkApproved represents a WorkApproved event raised by the Bindings contract.
+type BindingsWorkApproved struct {
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterWorkApproved is a free log retrieval operation binding the contract event 0xe021b70c90c04cb1a4fae9fbcf358044aab6b86f01086a2af93b8e5c33abc2b6.
+//
+// Solidity: event WorkApproved(uint256 amount)
+func (_Bindings *BindingsFilterer) FilterWorkApproved(opts *bind.FilterOpts) (*BindingsWorkApprovedIterator, error) {
+
+	logs, sub, err := _Bindings.contract.FilterLogs(opts, "WorkApproved")
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsWorkApprovedIterator{contract: _Bindings.contract, event: "WorkApproved", logs: logs, sub: sub}, nil
+}
+
+// WatchWorkApproved is a free log subscription operation binding the contract event 0xe021b70c90c04cb1a4fae9fbcf358044aab6b86f01086a2af93b8e5c33abc2b6.
+//
+// Solidity: event WorkApproved(uint256 amount)
+func (_Bindings *BindingsFilterer) WatchWorkApproved(opts *bind.WatchOpts, sink chan<- *BindingsWorkApproved) (event.Subscription, error) {
+
+	logs, sub, err := _Bindings.contract.WatchLogs(opts, "WorkApproved")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BindingsWorkApproved)
+				if err := _Bindings.contract.UnpackLog(event, "WorkApproved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWorkApproved is a log parse operation binding the contract event 0xe021b70c90c04cb1a4fae9fbcf358044aab6b86f01086a2af93b8e5c33abc2b6.
+//
+// Solidity: event WorkApproved(uint256 amount)
+func (_Bindings *BindingsFilterer) ParseWorkApproved(log types.Log) (*BindingsWorkApproved, error) {
+	event := new(BindingsWorkApproved)
+	if err := _Bindings.contract.UnpackLog(event, "WorkApproved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BindingsWorkSubmittedIterator is returned from FilterWorkSubmitted and is used to iterate over the raw logs and unpacked data for WorkSubmitted events raised by the Bindings contract.
+type BindingsWorkSubmittedIterator struct {
+	Event *BindingsWorkSubmitted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BindingsWorkSubmittedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BindingsWorkSubmitted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BindingsWorkSubmitted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BindingsWorkSubmittedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BindingsWorkSubmittedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BindingsWorkSubmitted represents a WorkSubmitted event raised by the Bindings contract.
+type BindingsWorkSubmitted struct {
+	WorkSubmission string
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterWorkSubmitted is a free log retrieval operation binding the contract event 0x9d0581297f0ce789714b600feee27278aa53d55e6834cf60265df447fb5bdb5f.
+//
+// Solidity: event WorkSubmitted(string workSubmission)
+func (_Bindings *BindingsFilterer) FilterWorkSubmitted(opts *bind.FilterOpts) (*BindingsWorkSubmittedIterator, error) {
+
+	logs, sub, err := _Bindings.contract.FilterLogs(opts, "WorkSubmitted")
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsWorkSubmittedIterator{contract: _Bindings.contract, event: "WorkSubmitted", logs: logs, sub: sub}, nil
+}
+
+// WatchWorkSubmitted is a free log subscription operation binding the contract event 0x9d0581297f0ce789714b600feee27278aa53d55e6834cf60265df447fb5bdb5f.
+//
+// Solidity: event WorkSubmitted(string workSubmission)
+func (_Bindings *BindingsFilterer) WatchWorkSubmitted(opts *bind.WatchOpts, sink chan<- *BindingsWorkSubmitted) (event.Subscription, error) {
+
+	logs, sub, err := _Bindings.contract.WatchLogs(opts, "WorkSubmitted")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BindingsWorkSubmitted)
+				if err := _Bindings.contract.UnpackLog(event, "WorkSubmitted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWorkSubmitted is a log parse operation binding the contract event 0x9d0581297f0ce789714b600feee27278aa53d55e6834cf60265df447fb5bdb5f.
+//
+// Solidity: event WorkSubmitted(string workSubmission)
+func (_Bindings *BindingsFilterer) ParseWorkSubmitted(log types.Log) (*BindingsWorkSubmitted, error) {
+	event := new(BindingsWorkSubmitted)
+	if err := _Bindings.contract.UnpackLog(event, "WorkSubmitted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
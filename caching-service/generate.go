@@ -0,0 +1,4 @@
+package main
+
+// Regenerate the contract bindings after `npx hardhat compile`.
+//go:generate go run ./cmd/bindgen -artifacts ../artifacts -out .
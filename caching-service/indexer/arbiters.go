@@ -0,0 +1,182 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/arbiterregistry"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+// Arbiters indexes the ArbiterRegistry into arbiters, with the raw events
+// kept in registry_events so the table can be rebuilt after a reorg.
+type Arbiters struct {
+	client   Client
+	chainID  int64
+	registry common.Address
+	decoder  *decoder
+
+	// profileHashes holds what Prepare read for added arbiters, until
+	// HandleLog stores it.
+	profileHashes map[common.Address]string
+}
+
+// NewArbiters creates the handler for the registry at registry on chainID.
+func NewArbiters(client Client, chainID int64, registry common.Address) (*Arbiters, error) {
+	filterer, err := arbiterregistry.NewBindingsFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bind arbiter registry: %w", err)
+	}
+	dec := newDecoder()
+	if err := dec.register(arbiterregistry.BindingsMetaData, map[string]parseFunc{
+		"ArbiterAdded":         parser(filterer.ParseArbiterAdded),
+		"ArbiterRemoved":       parser(filterer.ParseArbiterRemoved),
+		"OwnershipTransferred": parser(filterer.ParseOwnershipTransferred),
+	}); err != nil {
+		return nil, err
+	}
+	return &Arbiters{
+		client:        client,
+		chainID:       chainID,
+		registry:      registry,
+		decoder:       dec,
+		profileHashes: make(map[common.Address]string),
+	}, nil
+}
+
+func (a *Arbiters) Name() string { return fmt.Sprintf("arbiters@%d", a.chainID) }
+
+func (a *Arbiters) Contract() common.Address { return a.registry }
+
+func (a *Arbiters) Query() ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{a.registry},
+		Topics:    [][]common.Hash{a.decoder.topics()},
+	}
+}
+
+// Load is a no-op; the handler keeps no state outside the store.
+func (a *Arbiters) Load(ctx context.Context) error { return nil }
+
+func (a *Arbiters) HandleLog(ctx context.Context, tx store.Tx, vLog types.Log) (bool, error) {
+	if vLog.Address != a.registry {
+		return false, nil
+	}
+	event, err := a.decoder.decode(vLog)
+	if errors.Is(err, errUnknownEvent) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("decode log: %w", err)
+	}
+
+	switch e := event.(type) {
+	case *arbiterregistry.BindingsArbiterAdded:
+		return true, a.handleArbiterAdded(ctx, tx, e)
+	case *arbiterregistry.BindingsArbiterRemoved:
+		return true, a.handleArbiterRemoved(ctx, tx, e)
+	case *arbiterregistry.BindingsOwnershipTransferred:
+		inserted, err := tx.ApplyRegistryEvent(ctx, store.RegistryEvent{
+			ChainID:  a.chainID,
+			Name:     "OwnershipTransferred",
+			Address:  e.NewOwner,
+			Previous: &e.PreviousOwner,
+		}, vLog)
+		if err != nil || !inserted {
+			return true, err
+		}
+		fmt.Printf("📝 Arbiter registry ownership transferred to %s (block %d)\n", e.NewOwner.Hex(), vLog.BlockNumber)
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// Prepare reads the profile hash of the arbiter an ArbiterAdded log adds,
+// so the RPC call happens outside the store transaction. The event only
+// carries the name, so the hash is read from arbiters() at the latest block.
+func (a *Arbiters) Prepare(ctx context.Context, vLog types.Log) error {
+	if vLog.Address != a.registry {
+		return nil
+	}
+	event, err := a.decoder.decode(vLog)
+	if err != nil {
+		// HandleLog reports it.
+		return nil
+	}
+	added, ok := event.(*arbiterregistry.BindingsArbiterAdded)
+	if !ok {
+		return nil
+	}
+	caller, err := arbiterregistry.NewBindingsCaller(a.registry, a.client)
+	if err != nil {
+		return fmt.Errorf("bind arbiter registry: %w", err)
+	}
+	arbiter, err := caller.Arbiters(&bind.CallOpts{Context: ctx}, added.ArbiterAddress)
+	if err != nil {
+		return fmt.Errorf("read arbiter %s: %w", added.ArbiterAddress.Hex(), err)
+	}
+	a.profileHashes[added.ArbiterAddress] = arbiter.ProfileHash
+	return nil
+}
+
+// handleArbiterAdded records the arbiter as active, with the profile hash
+// Prepare read.
+func (a *Arbiters) handleArbiterAdded(ctx context.Context, tx store.Tx, event *arbiterregistry.BindingsArbiterAdded) error {
+	vLog := event.Raw
+
+	profileHash, ok := a.profileHashes[event.ArbiterAddress]
+	if !ok {
+		return fmt.Errorf("arbiter %s was not read before its ArbiterAdded", event.ArbiterAddress.Hex())
+	}
+	delete(a.profileHashes, event.ArbiterAddress)
+
+	inserted, err := tx.ApplyRegistryEvent(ctx, store.RegistryEvent{
+		ChainID:     a.chainID,
+		Name:        "ArbiterAdded",
+		Address:     event.ArbiterAddress,
+		ArbiterName: &event.Name,
+		ProfileHash: &profileHash,
+	}, vLog)
+	if err != nil {
+		return err
+	}
+	if !inserted {
+		skipApplied("ArbiterAdded", vLog)
+		return nil
+	}
+	fmt.Printf("📝 Arbiter %s (%s) added (block %d)\n", event.Name, event.ArbiterAddress.Hex(), vLog.BlockNumber)
+	return nil
+}
+
+func (a *Arbiters) handleArbiterRemoved(ctx context.Context, tx store.Tx, event *arbiterregistry.BindingsArbiterRemoved) error {
+	vLog := event.Raw
+	inserted, err := tx.ApplyRegistryEvent(ctx, store.RegistryEvent{
+		ChainID: a.chainID,
+		Name:    "ArbiterRemoved",
+		Address: event.ArbiterAddress,
+	}, vLog)
+	if err != nil {
+		return err
+	}
+	if !inserted {
+		skipApplied("ArbiterRemoved", vLog)
+		return nil
+	}
+	fmt.Printf("📝 Arbiter %s removed (block %d)\n", event.ArbiterAddress.Hex(), vLog.BlockNumber)
+	return nil
+}
+
+// Rollback rebuilds every arbiter touched by events from block `from`
+// onwards out of the events that remain, then deletes those events.
+func (a *Arbiters) Rollback(ctx context.Context, tx store.Tx, from uint64) error {
+	if err := tx.RollbackArbiters(ctx, a.chainID, from); err != nil {
+		return fmt.Errorf("roll back arbiters: %w", err)
+	}
+	return nil
+}
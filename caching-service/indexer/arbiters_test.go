@@ -0,0 +1,90 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/arbiterregistry"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+// registryChain answers arbiters() calls with profileHash, or fails them
+// while down.
+type registryChain struct {
+	*fakeChain
+	profileHash string
+	down        bool
+}
+
+func (c *registryChain) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if c.down {
+		return nil, errors.New("node unavailable")
+	}
+	parsed, err := arbiterregistry.BindingsMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Methods["arbiters"].Outputs.Pack("Arbiter", c.profileHash, true)
+}
+
+func arbiterAddedLog(t *testing.T, registry, arbiter common.Address, name string) types.Log {
+	t.Helper()
+	parsed, err := arbiterregistry.BindingsMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := parsed.Events["ArbiterAdded"]
+	data, err := event.Inputs.NonIndexed().Pack(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types.Log{
+		Address:     registry,
+		Topics:      []common.Hash{event.ID, common.BytesToHash(arbiter.Bytes())},
+		Data:        data,
+		BlockNumber: 7,
+		BlockHash:   common.HexToHash("0x7"),
+		TxHash:      common.HexToHash("0x7000"),
+	}
+}
+
+// An ArbiterAdded log whose profile hash can't be read fails, leaving
+// nothing stored, and is applied with the hash once the node answers.
+func TestArbiterAddedReadsProfileHash(t *testing.T) {
+	ctx := context.Background()
+	registry := common.HexToAddress("0xa7b")
+	arbiter := common.HexToAddress("0x90F79bf6EB2c4f870365E785982E1f101E93b906")
+	chain := &registryChain{fakeChain: newFakeChain(10), profileHash: "QmProfile", down: true}
+	h, err := NewArbiters(chain, testChainID, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := store.NewMemory()
+	ix := New(chain, st, h, Config{BatchSize: 100})
+	ix.chainID = testChainID
+	vLog := arbiterAddedLog(t, registry, arbiter, "Arbiter")
+
+	if err := ix.handleLog(ctx, vLog); err == nil {
+		t.Fatal("ArbiterAdded was applied without its profile hash")
+	}
+	if got, err := st.Arbiters(ctx, store.ArbiterQuery{ChainID: testChainID}); err != nil || len(got) != 0 {
+		t.Fatalf("after a failed read, arbiters = %+v, %v", got, err)
+	}
+
+	chain.down = false
+	if err := ix.handleLog(ctx, vLog); err != nil {
+		t.Fatal(err)
+	}
+	got, err := st.Arbiters(ctx, store.ArbiterQuery{ChainID: testChainID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Name != "Arbiter" || got[0].ProfileHash == nil || *got[0].ProfileHash != "QmProfile" {
+		t.Errorf("arbiters = %+v", got)
+	}
+}
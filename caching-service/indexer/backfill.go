@@ -0,0 +1,48 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// backfill replays the handler's logs in [from, to] in chunks of
+// cfg.BatchSize. If a log changes the handler's query, the rest of the chunk
+// is requested again with the new one.
+func (ix *Indexer) backfill(ctx context.Context, from, to uint64) error {
+	if from > to {
+		ix.synced = max(ix.synced, to)
+		return nil
+	}
+	fmt.Printf("⏪ [%s] Backfilling blocks %d to %d\n", ix.handler.Name(), from, to)
+
+	for start := from; start <= to; {
+		end := min(start+ix.cfg.BatchSize-1, to)
+		version := ix.queryVersion()
+		query := ix.handler.Query()
+		query.FromBlock = new(big.Int).SetUint64(start)
+		query.ToBlock = new(big.Int).SetUint64(end)
+
+		logs, err := ix.client.FilterLogs(ctx, query)
+		if err != nil {
+			return fmt.Errorf("filter logs %d-%d: %w", start, end, err)
+		}
+		next := end + 1
+		for _, vLog := range logs {
+			if err := ix.handleLog(ctx, vLog); err != nil {
+				return err
+			}
+			if ix.queryVersion() != version {
+				// Later logs in this block may come from the contract that
+				// is now watched; Covers skips the ones already applied.
+				next = vLog.BlockNumber
+				break
+			}
+		}
+		start = next
+	}
+
+	ix.synced = to
+	fmt.Printf("✅ [%s] Backfill complete up to block %d\n", ix.handler.Name(), to)
+	return nil
+}
@@ -0,0 +1,35 @@
+package indexer
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBackfillQueriesNewContracts(t *testing.T) {
+	chain := newFakeChain(20)
+	h := newTestHandler()
+	child := common.HexToAddress("0xc41d")
+	chain.addLog(h.factory, 5, 0, child.Bytes()...)
+	chain.addLog(child, 5, 1)
+	chain.addLog(child, 7, 0)
+	chain.addLog(common.HexToAddress("0x0dd"), 8, 0)
+
+	ix := newTestIndexer(chain, h)
+	if err := ix.backfill(context.Background(), 0, 20); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := h.appliedBlocks(), []uint64{5, 5, 7}; !slices.Equal(got, want) {
+		t.Errorf("applied logs from blocks %v, want %v", got, want)
+	}
+	// The second query starts at the block that added the child and covers
+	// it too.
+	if len(chain.queries) != 2 || chain.queries[1].FromBlock.Uint64() != 5 || len(chain.queries[1].Addresses) != 2 {
+		t.Errorf("queries = %+v", chain.queries)
+	}
+	if ix.synced != 20 {
+		t.Errorf("synced = %d, want 20", ix.synced)
+	}
+}
@@ -0,0 +1,246 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+const testChainID = 31337
+
+// fakeChain is a Client over a chain of headers and logs held in memory.
+// Subscriptions receive only what the test sends them.
+type fakeChain struct {
+	mu      sync.Mutex
+	headers []*types.Header
+	logs    []types.Log
+	queries []ethereum.FilterQuery
+	// deployed holds the block each contract was deployed in.
+	deployed map[common.Address]uint64
+	// prunedBelow makes CodeAt fail for older blocks, like a node that
+	// doesn't keep old state.
+	prunedBelow uint64
+
+	logSubs  []chan<- types.Log
+	headSubs []chan<- *types.Header
+}
+
+// newFakeChain returns a chain of blocks 0 to head.
+func newFakeChain(head uint64) *fakeChain {
+	c := &fakeChain{}
+	c.extend(0, head, 0)
+	return c
+}
+
+// extend replaces the blocks from `from` onwards with new ones up to head.
+// A different fork value gives them different hashes.
+func (c *fakeChain) extend(from, head uint64, fork byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.headers = c.headers[:from]
+	for n := from; n <= head; n++ {
+		hdr := &types.Header{Number: new(big.Int).SetUint64(n), Extra: []byte{fork}}
+		if n > 0 {
+			hdr.ParentHash = c.headers[n-1].Hash()
+		}
+		c.headers = append(c.headers, hdr)
+	}
+	c.logs = slices.DeleteFunc(c.logs, func(l types.Log) bool { return l.BlockNumber >= from })
+}
+
+// addLog puts a log from contract into block n and returns it.
+func (c *fakeChain) addLog(contract common.Address, n uint64, index uint, data ...byte) types.Log {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	vLog := types.Log{
+		Address:     contract,
+		Data:        data,
+		BlockNumber: n,
+		BlockHash:   c.headers[n].Hash(),
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(n*1000 + uint64(index))),
+		Index:       index,
+	}
+	c.logs = append(c.logs, vLog)
+	return vLog
+}
+
+func (c *fakeChain) header(n uint64) *types.Header {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.headers[n]
+}
+
+func (c *fakeChain) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(testChainID), nil
+}
+
+func (c *fakeChain) BlockNumber(ctx context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return uint64(len(c.headers) - 1), nil
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	if !number.IsUint64() || number.Uint64() >= uint64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *fakeChain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queries = append(c.queries, q)
+	var logs []types.Log
+	for _, l := range c.logs {
+		if l.BlockNumber < q.FromBlock.Uint64() || l.BlockNumber > q.ToBlock.Uint64() {
+			continue
+		}
+		if len(q.Addresses) > 0 && !slices.Contains(q.Addresses, l.Address) {
+			continue
+		}
+		logs = append(logs, l)
+	}
+	slices.SortFunc(logs, func(a, b types.Log) int {
+		if a.BlockNumber != b.BlockNumber {
+			return int(a.BlockNumber) - int(b.BlockNumber)
+		}
+		return int(a.Index) - int(b.Index)
+	})
+	return logs, nil
+}
+
+func (c *fakeChain) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logSubs = append(c.logSubs, ch)
+	return idleSubscription(), nil
+}
+
+func (c *fakeChain) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.headSubs = append(c.headSubs, ch)
+	return idleSubscription(), nil
+}
+
+func idleSubscription() ethereum.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
+// deploy gives contract code from block n onwards.
+func (c *fakeChain) deploy(contract common.Address, n uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.deployed == nil {
+		c.deployed = map[common.Address]uint64{}
+	}
+	c.deployed[contract] = n
+}
+
+// queried returns the queries FilterLogs has had so far.
+func (c *fakeChain) queried() []ethereum.FilterQuery {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.queries)
+}
+
+func (c *fakeChain) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if blockNumber.Uint64() < c.prunedBelow {
+		return nil, errors.New("missing trie node")
+	}
+	if n, ok := c.deployed[contract]; ok && blockNumber.Uint64() >= n {
+		return []byte{0x60}, nil
+	}
+	return nil, nil
+}
+
+func (c *fakeChain) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, errors.New("fake chain has no contracts")
+}
+
+// testHandler applies every log from the contracts it watches. A log from
+// the factory watches the address in its data, like an EscrowCreated.
+type testHandler struct {
+	factory common.Address
+	watched []common.Address
+	applied []types.Log
+	// rollbacks lists the from block of every Rollback.
+	rollbacks []uint64
+	// fail makes HandleLog fail for logs from this block.
+	fail uint64
+}
+
+func newTestHandler() *testHandler {
+	factory := common.HexToAddress("0xfac")
+	return &testHandler{factory: factory, watched: []common.Address{factory}}
+}
+
+func (h *testHandler) Name() string { return "test" }
+
+func (h *testHandler) Contract() common.Address { return h.factory }
+
+func (h *testHandler) Query() ethereum.FilterQuery {
+	return ethereum.FilterQuery{Addresses: slices.Clone(h.watched)}
+}
+
+func (h *testHandler) QueryVersion() int { return len(h.watched) }
+
+func (h *testHandler) Load(ctx context.Context) error { return nil }
+
+func (h *testHandler) HandleLog(ctx context.Context, tx store.Tx, vLog types.Log) (bool, error) {
+	if !slices.Contains(h.watched, vLog.Address) {
+		return false, nil
+	}
+	if h.fail != 0 && vLog.BlockNumber == h.fail {
+		return false, fmt.Errorf("block %d is broken", vLog.BlockNumber)
+	}
+	if vLog.Address == h.factory && len(vLog.Data) > 0 {
+		h.watched = append(h.watched, common.BytesToAddress(vLog.Data))
+	}
+	h.applied = append(h.applied, vLog)
+	return true, nil
+}
+
+func (h *testHandler) Rollback(ctx context.Context, tx store.Tx, from uint64) error {
+	h.rollbacks = append(h.rollbacks, from)
+	h.applied = slices.DeleteFunc(h.applied, func(l types.Log) bool { return l.BlockNumber >= from })
+	return nil
+}
+
+// appliedBlocks lists the block of every applied log, in order.
+func (h *testHandler) appliedBlocks() []uint64 {
+	blocks := make([]uint64, len(h.applied))
+	for i, l := range h.applied {
+		blocks[i] = l.BlockNumber
+	}
+	return blocks
+}
+
+// newTestIndexer returns an indexer over chain and a memory store, set up as
+// a session would leave it before backfilling.
+func newTestIndexer(chain *fakeChain, h *testHandler) *Indexer {
+	ix := New(chain, store.NewMemory(), h, Config{BatchSize: 100})
+	ix.chainID = testChainID
+	ix.headers = headerCache{}
+	return ix
+}
@@ -0,0 +1,34 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+// confirmationStatus returns the status a row ingested from block n should
+// be written with, given the latest head the indexer has seen.
+func (d *Deals) confirmationStatus(n uint64) string {
+	if n+d.confirmationDepth <= d.latest {
+		return store.StatusConfirmed
+	}
+	return store.StatusPending
+}
+
+// OnHead marks pending deals as confirmed once confirmationDepth blocks have
+// been built on top of them.
+func (d *Deals) OnHead(ctx context.Context, latest uint64) error {
+	d.latest = latest
+	if d.latest < d.confirmationDepth {
+		return nil
+	}
+	n, err := d.store.ConfirmDeals(ctx, d.chainID, d.latest-d.confirmationDepth)
+	if err != nil {
+		return fmt.Errorf("promote confirmed deals: %w", err)
+	}
+	if n > 0 {
+		fmt.Printf("✔️  %d deal(s) on chain %d reached %d confirmations\n", n, d.chainID, d.confirmationDepth)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/escrowfactory"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+// Deals indexes EscrowFactory.EscrowCreated into deals and the lifecycle
+// events of every escrow the factory creates into deal_events.
+type Deals struct {
+	client  Client
+	store   store.Store
+	chainID int64
+	factory common.Address
+	// confirmationDepth is how many blocks a deal stays pending.
+	confirmationDepth uint64
+	decoder           *decoder
+
+	// latest is the newest head the indexer has reported.
+	latest uint64
+	// escrows is the set of escrow contracts whose events are indexed.
+	escrows map[common.Address]struct{}
+	// version counts the changes to escrows, for QueryVersion.
+	version int
+	// details holds what Prepare read from new escrows, until HandleLog
+	// stores it.
+	details map[common.Address]*escrowDetails
+}
+
+// NewDeals creates the handler for the factory at factory on chainID.
+func NewDeals(client Client, st store.Store, chainID int64, factory common.Address, confirmationDepth uint64) (*Deals, error) {
+	dec, err := newDealsDecoder()
+	if err != nil {
+		return nil, err
+	}
+	return &Deals{
+		client:            client,
+		store:             st,
+		chainID:           chainID,
+		factory:           factory,
+		confirmationDepth: confirmationDepth,
+		decoder:           dec,
+		escrows:           make(map[common.Address]struct{}),
+		details:           make(map[common.Address]*escrowDetails),
+	}, nil
+}
+
+func (d *Deals) Name() string { return fmt.Sprintf("deals@%d", d.chainID) }
+
+func (d *Deals) Contract() common.Address { return d.factory }
+
+// Query matches the factory and every escrow it created. The escrows are
+// created at runtime, so the query grows as deals are indexed.
+func (d *Deals) Query() ethereum.FilterQuery {
+	addresses := make([]common.Address, 0, len(d.escrows)+1)
+	addresses = append(addresses, d.factory)
+	for escrow := range d.escrows {
+		addresses = append(addresses, escrow)
+	}
+	return ethereum.FilterQuery{
+		Addresses: addresses,
+		Topics:    [][]common.Hash{d.decoder.topics()},
+	}
+}
+
+func (d *Deals) QueryVersion() int { return d.version }
+
+// Load fills the watched set with every escrow already stored.
+func (d *Deals) Load(ctx context.Context) error {
+	escrows, err := d.store.Escrows(ctx, d.chainID)
+	if err != nil {
+		return fmt.Errorf("load escrows: %w", err)
+	}
+	watched := make(map[common.Address]struct{}, len(escrows))
+	for _, escrow := range escrows {
+		watched[escrow] = struct{}{}
+	}
+	if !maps.Equal(watched, d.escrows) {
+		d.escrows = watched
+		d.version++
+	}
+	return nil
+}
+
+func (d *Deals) HandleLog(ctx context.Context, tx store.Tx, vLog types.Log) (bool, error) {
+	event, err := d.decoder.decode(vLog)
+	if errors.Is(err, errUnknownEvent) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("decode log: %w", err)
+	}
+
+	switch e := event.(type) {
+	case *escrowfactory.BindingsEscrowCreated:
+		// Another factory deployment emits the same event; only ours counts.
+		if vLog.Address != d.factory {
+			return false, nil
+		}
+		return true, d.handleEscrowCreated(ctx, tx, e)
+	default:
+		if _, ok := d.escrows[vLog.Address]; !ok {
+			return false, nil
+		}
+		return d.handleEscrowEvent(ctx, tx, vLog, event)
+	}
+}
+
+// Prepare reads the details of the escrow an EscrowCreated log deploys, so
+// the RPC calls happen outside the store transaction. Escrows that are
+// already stored aren't read again.
+func (d *Deals) Prepare(ctx context.Context, vLog types.Log) error {
+	if vLog.Address != d.factory {
+		return nil
+	}
+	event, err := d.decoder.decode(vLog)
+	if err != nil {
+		// HandleLog reports it.
+		return nil
+	}
+	created, ok := event.(*escrowfactory.BindingsEscrowCreated)
+	if !ok {
+		return nil
+	}
+	if _, ok := d.escrows[created.EscrowAddress]; ok {
+		return nil
+	}
+	details, err := d.fetchEscrowDetails(ctx, created.EscrowAddress)
+	if err != nil {
+		return fmt.Errorf("read escrow %s: %w", created.EscrowAddress.Hex(), err)
+	}
+	d.details[created.EscrowAddress] = details
+	return nil
+}
+
+// Rollback deletes the deals and deal events ingested from block `from`
+// onwards and recomputes the status of deals that lose events.
+func (d *Deals) Rollback(ctx context.Context, tx store.Tx, from uint64) error {
+	if err := tx.RollbackDeals(ctx, d.chainID, from); err != nil {
+		return fmt.Errorf("roll back deals: %w", err)
+	}
+	return nil
+}
+
+// handleEscrowCreated stores a new deal and starts watching its escrow.
+func (d *Deals) handleEscrowCreated(ctx context.Context, tx store.Tx, event *escrowfactory.BindingsEscrowCreated) error {
+	vLog := event.Raw
+
+	details, ok := d.details[event.EscrowAddress]
+	if !ok {
+		// Prepare skips escrows that are already stored.
+		if _, stored := d.escrows[event.EscrowAddress]; stored {
+			skipApplied("EscrowCreated", vLog)
+			return nil
+		}
+		return fmt.Errorf("escrow %s was not read before its EscrowCreated", event.EscrowAddress.Hex())
+	}
+	delete(d.details, event.EscrowAddress)
+
+	fmt.Println("\n-----------------------------------------")
+	fmt.Println("🔥 New EscrowCreated Event Received!")
+
+	inserted, err := tx.InsertDeal(ctx, d.newDeal(event, details, vLog), vLog)
+	if err != nil {
+		return fmt.Errorf("insert deal: %w", err)
+	}
+	if _, ok := d.escrows[event.EscrowAddress]; !ok {
+		d.escrows[event.EscrowAddress] = struct{}{}
+		d.version++
+	}
+	if !inserted {
+		skipApplied("EscrowCreated", vLog)
+		return nil
+	}
+	if err := tx.NotifyDealUpdate(ctx, d.chainID, event.EscrowAddress, "EscrowCreated", vLog); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Deal successfully stored in the database.")
+
+	fmt.Printf("   Escrow Contract Address: %s\n", event.EscrowAddress.Hex())
+	fmt.Printf("   Client Address: %s\n", event.Client.Hex())
+	fmt.Printf("   Freelancer Address: %s\n", event.Freelancer.Hex())
+	fmt.Printf("   Arbiter Address: %s\n", details.Arbiter.Hex())
+	fmt.Printf("   Token Address: %s\n", details.Token.Hex())
+	fmt.Printf("   Total Amount: %v\n", event.TotalAmount)
+	fmt.Println("-----------------------------------------")
+	return nil
+}
+
+// newDeal builds the deal row for event. A new deal is always in the created
+// status; the escrow's own events move it on from there.
+func (d *Deals) newDeal(event *escrowfactory.BindingsEscrowCreated, details *escrowDetails, vLog types.Log) store.NewDeal {
+	return store.NewDeal{
+		ChainID:            d.chainID,
+		Escrow:             event.EscrowAddress,
+		Client:             event.Client,
+		Freelancer:         event.Freelancer,
+		Arbiter:            details.Arbiter,
+		TotalAmount:        event.TotalAmount,
+		Token:              &details.Token,
+		ProjectDescription: &details.ProjectDescription,
+		Status:             store.DealCreated,
+		ConfirmationStatus: d.confirmationStatus(vLog.BlockNumber),
+	}
+}
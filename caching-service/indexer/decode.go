@@ -0,0 +1,111 @@
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/escrowfactory"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/escrowsimple"
+)
+
+// errUnknownEvent is returned for logs whose first topic doesn't match any
+// event the decoder knows about.
+var errUnknownEvent = errors.New("unknown event")
+
+type parseFunc func(types.Log) (any, error)
+
+// parser adapts a generated ParseX method to a parseFunc.
+func parser[T any](parse func(types.Log) (*T, error)) parseFunc {
+	return func(vLog types.Log) (any, error) {
+		event, err := parse(vLog)
+		if err != nil {
+			return nil, err
+		}
+		return event, nil
+	}
+}
+
+// decoder turns raw logs into the typed events of the generated bindings.
+//
+// Indexed event arguments are stored in the log topics rather than the data
+// section, so decoding must go through the bindings' Parse methods (which use
+// abi.ParseTopics) instead of unpacking vLog.Data on its own.
+type decoder struct {
+	parsers map[common.Hash]parseFunc
+}
+
+func newDecoder() *decoder {
+	return &decoder{parsers: make(map[common.Hash]parseFunc)}
+}
+
+// newDealsDecoder decodes EscrowCreated and the EscrowSimple lifecycle events.
+func newDealsDecoder() (*decoder, error) {
+	// Parsing never touches the backend or the address, so neither is needed.
+	factory, err := escrowfactory.NewBindingsFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bind escrow factory: %w", err)
+	}
+	escrow, err := escrowsimple.NewBindingsFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bind escrow: %w", err)
+	}
+
+	d := newDecoder()
+	if err := d.register(escrowfactory.BindingsMetaData, map[string]parseFunc{
+		"EscrowCreated": parser(factory.ParseEscrowCreated),
+	}); err != nil {
+		return nil, err
+	}
+	if err := d.register(escrowsimple.BindingsMetaData, map[string]parseFunc{
+		"AgreementFunded": parser(escrow.ParseAgreementFunded),
+		"WorkSubmitted":   parser(escrow.ParseWorkSubmitted),
+		"WorkApproved":    parser(escrow.ParseWorkApproved),
+		"DisputeRaised":   parser(escrow.ParseDisputeRaised),
+		"DisputeResolved": parser(escrow.ParseDisputeResolved),
+	}); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// register maps the topic of each named event in meta's ABI to its parser.
+func (d *decoder) register(meta *bind.MetaData, parsers map[string]parseFunc) error {
+	contractAbi, err := abi.JSON(strings.NewReader(meta.ABI))
+	if err != nil {
+		return fmt.Errorf("parse ABI: %w", err)
+	}
+	for name, parse := range parsers {
+		event, ok := contractAbi.Events[name]
+		if !ok {
+			return fmt.Errorf("event %s not in ABI", name)
+		}
+		d.parsers[event.ID] = parse
+	}
+	return nil
+}
+
+// topics returns the signature topic of every event the decoder handles.
+func (d *decoder) topics() []common.Hash {
+	topics := make([]common.Hash, 0, len(d.parsers))
+	for topic := range d.parsers {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// decode returns the typed event for vLog, e.g. *escrowfactory.BindingsEscrowCreated.
+func (d *decoder) decode(vLog types.Log) (any, error) {
+	if len(vLog.Topics) == 0 {
+		return nil, errUnknownEvent
+	}
+	parse, ok := d.parsers[vLog.Topics[0]]
+	if !ok {
+		return nil, errUnknownEvent
+	}
+	return parse(vLog)
+}
@@ -0,0 +1,97 @@
+package indexer
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/escrowfactory"
+)
+
+func loadLogs(t *testing.T, name string) []types.Log {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logs []types.Log
+	if err := json.Unmarshal(raw, &logs); err != nil {
+		t.Fatal(err)
+	}
+	return logs
+}
+
+func TestDecodeEscrowCreated(t *testing.T) {
+	dec, err := newDealsDecoder()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		escrow, client, freelancer string
+		amount                     string
+	}{
+		{
+			escrow:     "0x94099942864EA81cCF197E9D71ac53310b1468D8",
+			client:     "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+			freelancer: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+			amount:     "100000000000000000000",
+		},
+		{
+			escrow:     "0xD8a5a9b31c3C0232E196d518E89Fd8bF83AcAd43",
+			client:     "0x90F79bf6EB2c4f870365E785982E1f101E93b906",
+			freelancer: "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65",
+			amount:     "2500000000000000000",
+		},
+	}
+
+	logs := loadLogs(t, "escrow_created_logs.json")
+	if len(logs) != len(want) {
+		t.Fatalf("got %d recorded logs, want %d", len(logs), len(want))
+	}
+	for i, vLog := range logs {
+		decoded, err := dec.decode(vLog)
+		if err != nil {
+			t.Fatalf("log %d: %v", i, err)
+		}
+		event, ok := decoded.(*escrowfactory.BindingsEscrowCreated)
+		if !ok {
+			t.Fatalf("log %d: decoded to %T", i, decoded)
+		}
+		if got, w := event.EscrowAddress, common.HexToAddress(want[i].escrow); got != w {
+			t.Errorf("log %d: escrowAddress = %s, want %s", i, got.Hex(), w.Hex())
+		}
+		if got, w := event.Client, common.HexToAddress(want[i].client); got != w {
+			t.Errorf("log %d: client = %s, want %s", i, got.Hex(), w.Hex())
+		}
+		if got, w := event.Freelancer, common.HexToAddress(want[i].freelancer); got != w {
+			t.Errorf("log %d: freelancer = %s, want %s", i, got.Hex(), w.Hex())
+		}
+		w, _ := new(big.Int).SetString(want[i].amount, 10)
+		if event.TotalAmount.Cmp(w) != 0 {
+			t.Errorf("log %d: totalAmount = %s, want %s", i, event.TotalAmount, w)
+		}
+		if event.Raw.TxHash != vLog.TxHash || event.Raw.Index != vLog.Index {
+			t.Errorf("log %d: raw log not preserved", i)
+		}
+	}
+}
+
+func TestDecodeUnknownEvent(t *testing.T) {
+	dec, err := newDealsDecoder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vLog := loadLogs(t, "escrow_created_logs.json")[0]
+	vLog.Topics[0] = common.HexToHash("0x01")
+	if _, err := dec.decode(vLog); !errors.Is(err, errUnknownEvent) {
+		t.Fatalf("decode = %v, want errUnknownEvent", err)
+	}
+	if _, err := dec.decode(types.Log{}); !errors.Is(err, errUnknownEvent) {
+		t.Fatalf("decode of log without topics = %v, want errUnknownEvent", err)
+	}
+}
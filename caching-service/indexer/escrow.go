@@ -0,0 +1,88 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/escrowsimple"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+// escrowDetails holds the deal fields EscrowCreated doesn't carry, read from
+// the new escrow contract itself.
+type escrowDetails struct {
+	Arbiter            common.Address
+	Token              common.Address
+	ProjectDescription string
+}
+
+// fetchEscrowDetails calls the escrow's getters at the latest block.
+// arbiter, token and projectDescription never change after deployment, so
+// there is no need to read historical state (which most hosted nodes prune).
+// The status does change, and is left to the escrow's events: read at the
+// latest block it would be ahead of a deal that is still being backfilled.
+func (d *Deals) fetchEscrowDetails(ctx context.Context, escrow common.Address) (*escrowDetails, error) {
+	contract, err := escrowsimple.NewBindingsCaller(escrow, d.client)
+	if err != nil {
+		return nil, fmt.Errorf("bind escrow %s: %w", escrow.Hex(), err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	var details escrowDetails
+	if details.Arbiter, err = contract.Arbiter(opts); err != nil {
+		return nil, fmt.Errorf("call arbiter(): %w", err)
+	}
+	if details.Token, err = contract.Token(opts); err != nil {
+		return nil, fmt.Errorf("call token(): %w", err)
+	}
+	if details.ProjectDescription, err = contract.ProjectDescription(opts); err != nil {
+		return nil, fmt.Errorf("call projectDescription(): %w", err)
+	}
+	return &details, nil
+}
+
+// newDealEvent describes an escrow event as a deal_events row. Every
+// EscrowSimple event moves the agreement to a fixed status, so it can be
+// derived from the event alone.
+func newDealEvent(event any) (*store.NewDealEvent, bool) {
+	switch e := event.(type) {
+	case *escrowsimple.BindingsAgreementFunded:
+		return &store.NewDealEvent{Name: "AgreementFunded", Amount: e.Amount, Status: store.DealFunded}, true
+	case *escrowsimple.BindingsWorkSubmitted:
+		return &store.NewDealEvent{Name: "WorkSubmitted", WorkSubmission: &e.WorkSubmission, Status: store.DealInProgress}, true
+	case *escrowsimple.BindingsWorkApproved:
+		return &store.NewDealEvent{Name: "WorkApproved", Amount: e.Amount, Status: store.DealCompleted}, true
+	case *escrowsimple.BindingsDisputeRaised:
+		return &store.NewDealEvent{Name: "DisputeRaised", Actor: &e.RaisedBy, Status: store.DealDisputed}, true
+	case *escrowsimple.BindingsDisputeResolved:
+		return &store.NewDealEvent{Name: "DisputeResolved", Actor: &e.Winner, Amount: e.Amount, Status: store.DealCompleted}, true
+	default:
+		return nil, false
+	}
+}
+
+// handleEscrowEvent appends an escrow lifecycle event to deal_events and
+// moves the deal to the status it implies.
+func (d *Deals) handleEscrowEvent(ctx context.Context, tx store.Tx, vLog types.Log, event any) (bool, error) {
+	de, ok := newDealEvent(event)
+	if !ok {
+		return false, nil
+	}
+	de.ChainID, de.Escrow = d.chainID, vLog.Address
+	inserted, err := tx.ApplyDealEvent(ctx, *de, vLog)
+	if err != nil {
+		return true, fmt.Errorf("insert %s for %s: %w", de.Name, vLog.Address.Hex(), err)
+	}
+	if !inserted {
+		skipApplied(de.Name, vLog)
+		return true, nil
+	}
+	if err := tx.NotifyDealUpdate(ctx, d.chainID, vLog.Address, de.Name, vLog); err != nil {
+		return true, err
+	}
+	fmt.Printf("📝 %s on escrow %s (block %d)\n", de.Name, vLog.Address.Hex(), vLog.BlockNumber)
+	return true, nil
+}
@@ -0,0 +1,87 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+// handleLog applies a single log through the handler, together with its
+// indexed_logs row and the checkpoint update, in one transaction. The store
+// ignores logs it already holds, so a log delivered twice is applied once.
+//
+// A failure ends the session rather than moving on: a later log would
+// advance the checkpoint past this one and it would never be retried. Run
+// starts a new session from the last committed checkpoint, which also
+// reloads any handler state the failed write touched.
+func (ix *Indexer) handleLog(ctx context.Context, vLog types.Log) error {
+	if ix.checkpoint.Covers(vLog) {
+		return nil
+	}
+	if err := ix.apply(ctx, vLog); err != nil {
+		return fmt.Errorf("apply log %s:%d: %w", vLog.TxHash.Hex(), vLog.Index, err)
+	}
+	return nil
+}
+
+// onLog handles a log from the live subscription. Logs at or below the
+// backfilled head were already handled by backfill; removed logs roll back
+// the blocks they came from, together with any others queued in logs.
+func (ix *Indexer) onLog(ctx context.Context, vLog types.Log, logs <-chan types.Log) error {
+	if vLog.Removed {
+		rest, err := ix.onRemovedLogs(ctx, vLog, logs)
+		if err != nil {
+			return err
+		}
+		for _, vLog := range rest {
+			if err := ix.onLog(ctx, vLog, logs); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if vLog.BlockNumber <= ix.synced {
+		return nil
+	}
+	return ix.handleLog(ctx, vLog)
+}
+
+func (ix *Indexer) apply(ctx context.Context, vLog types.Log) error {
+	if p, ok := ix.handler.(Preparer); ok {
+		if err := p.Prepare(ctx, vLog); err != nil {
+			return err
+		}
+	}
+	tx, err := ix.store.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	applied, err := ix.handler.HandleLog(ctx, tx, vLog)
+	if err != nil || !applied {
+		return err
+	}
+	if err := tx.RecordLog(ctx, ix.chainID, ix.handler.Contract(), vLog); err != nil {
+		return fmt.Errorf("record indexed log: %w", err)
+	}
+	cp := store.CheckpointOf(vLog)
+	// Another process writing the same store may already be further on;
+	// the checkpoint only moves forward.
+	if err := tx.AdvanceCheckpoint(ctx, ix.chainID, ix.handler.Contract(), cp); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	ix.checkpoint = &cp
+	return nil
+}
+
+// skipApplied notes a log the store already holds, which replays, backfills
+// and reconnects hand over again.
+func skipApplied(event string, vLog types.Log) {
+	fmt.Printf("⏭️  %s at %s:%d already applied, skipping\n", event, vLog.TxHash.Hex(), vLog.Index)
+}
@@ -0,0 +1,250 @@
+// Package indexer follows contract events on chain and applies them to the
+// caching service's store. Each contract (with any children it discovers,
+// like the escrows a factory creates) gets its own Indexer with its own
+// checkpoint; a Handler supplies the contract-specific decoding and writes.
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+// DefaultBatchSize is the number of blocks requested per eth_getLogs call
+// during backfill. Most hosted providers cap ranges somewhere above this.
+const DefaultBatchSize = 2000
+
+// Config controls where the indexer starts and how it pages through history.
+type Config struct {
+	// StartBlock is only used on the first run; afterwards the indexer
+	// resumes from its checkpoint.
+	StartBlock uint64
+	BatchSize  uint64
+	// Health, if set, receives each indexer's connection state.
+	Health *Health
+	// Source delivers live logs and heads. Nil means the client's own
+	// WebSocket subscriptions.
+	Source Source
+}
+
+// Handler applies one contract's events to the store.
+type Handler interface {
+	// Name is used in log output.
+	Name() string
+	// Contract is the address the indexer's checkpoint is stored under.
+	Contract() common.Address
+	// Query selects the logs the handler wants; block ranges are set by the
+	// indexer.
+	Query() ethereum.FilterQuery
+	// Load rebuilds any in-memory state from the store. It is called at the
+	// start of every session and after a rollback.
+	Load(ctx context.Context) error
+	// HandleLog applies vLog inside tx and reports whether the log was
+	// relevant. Irrelevant logs don't move the checkpoint.
+	HandleLog(ctx context.Context, tx store.Tx, vLog types.Log) (bool, error)
+	// Rollback removes everything derived from blocks at or after from.
+	Rollback(ctx context.Context, tx store.Tx, from uint64) error
+}
+
+// QueryTracker is implemented by handlers whose Query changes as logs are
+// applied, like Deals watching every escrow its factory creates. Whenever
+// QueryVersion changes the indexer queries again from the current log, and
+// resubscribes, so logs of newly watched contracts aren't missed.
+type QueryTracker interface {
+	QueryVersion() int
+}
+
+// Preparer is implemented by handlers that read contract state for a log.
+// Prepare runs before the log's transaction is opened, so the store isn't
+// held while waiting on the node. An error fails the log like one from
+// HandleLog.
+type Preparer interface {
+	Prepare(ctx context.Context, vLog types.Log) error
+}
+
+// HeadObserver is implemented by handlers that act on new chain heads, for
+// example to promote rows once they have enough confirmations.
+type HeadObserver interface {
+	OnHead(ctx context.Context, latest uint64) error
+}
+
+// Indexer backfills a contract's historical events and then follows the
+// chain head through a log subscription.
+type Indexer struct {
+	client  Client
+	source  Source
+	store   store.Store
+	cfg     Config
+	handler Handler
+
+	chainID    int64
+	checkpoint *store.Checkpoint
+	// synced is the highest block covered by backfill; live logs at or
+	// below it have already been handled.
+	synced  uint64
+	headers headerCache
+	// subscribed is the QueryVersion the log subscription was opened with.
+	subscribed int
+	clock      clock
+}
+
+// New creates an Indexer that feeds handler.
+func New(client Client, st store.Store, handler Handler, cfg Config) *Indexer {
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	source := cfg.Source
+	if source == nil {
+		source = client
+	}
+	return &Indexer{client: client, source: source, store: st, cfg: cfg, handler: handler, clock: realClock{}}
+}
+
+// session backfills from the stored checkpoint (or the configured start block
+// on first run) up to the current head and then processes live logs until ctx
+// is cancelled or the subscription fails. Run restarts it after failures.
+//
+// The subscription is opened before the head is read, so every block after
+// the backfilled range is guaranteed to arrive on it. Live logs at or below
+// the backfilled head are dropped because backfill already handled them.
+//
+// Reorgs are detected from removed logs and from new heads whose parent hash
+// doesn't match the recorded chain; either way the affected rows are rolled
+// back and the canonical logs re-ingested.
+func (ix *Indexer) session(ctx context.Context) error {
+	chainID, err := ix.client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("get chain ID: %w", err)
+	}
+	ix.chainID = chainID.Int64()
+
+	ix.checkpoint, err = ix.store.Checkpoint(ctx, ix.chainID, ix.handler.Contract())
+	if err != nil {
+		return fmt.Errorf("load checkpoint: %w", err)
+	}
+	if err := ix.verifyCheckpoint(ctx); err != nil {
+		return err
+	}
+	if err := ix.handler.Load(ctx); err != nil {
+		return err
+	}
+	if ix.checkpoint != nil {
+		fmt.Printf("📍 [%s] Resuming from checkpoint at block %d, log %d\n", ix.handler.Name(), ix.checkpoint.Block, ix.checkpoint.LogIndex)
+	}
+
+	logs := make(chan types.Log, 1024)
+	sub, err := ix.subscribeLogs(ctx, logs)
+	if err != nil {
+		return err
+	}
+	defer func() { sub.Unsubscribe() }()
+
+	heads := make(chan *types.Header, 64)
+	headSub, err := ix.source.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return fmt.Errorf("subscribe to new heads: %w", err)
+	}
+	defer headSub.Unsubscribe()
+
+	head, err := ix.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("get head block: %w", err)
+	}
+	ix.headers = headerCache{}
+	ix.headers.add(head)
+	if err := ix.notifyHead(ctx, head.Number.Uint64()); err != nil {
+		return err
+	}
+	ix.setState(StateBackfilling, nil)
+	if err := ix.backfill(ctx, ix.resumeBlock(), head.Number.Uint64()); err != nil {
+		return err
+	}
+	if err := ix.notifyHead(ctx, head.Number.Uint64()); err != nil {
+		return err
+	}
+
+	fmt.Printf("🎧 [%s] Listening for events\n", ix.handler.Name())
+	ix.setState(StateLive, nil)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return err
+		case err := <-headSub.Err():
+			return err
+		case header := <-heads:
+			if err := ix.onHead(ctx, header); err != nil {
+				return err
+			}
+		case vLog := <-logs:
+			if err := ix.onLog(ctx, vLog, logs); err != nil {
+				return err
+			}
+		}
+		if ix.queryVersion() != ix.subscribed {
+			if sub, logs, err = ix.resubscribe(ctx, sub); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// resumeBlock is where to start reading logs: the checkpoint block itself,
+// since logs later in that block may not have been processed yet and Covers
+// skips the ones that were, or the start block on the first run.
+func (ix *Indexer) resumeBlock() uint64 {
+	if ix.checkpoint == nil {
+		return ix.cfg.StartBlock
+	}
+	return max(ix.cfg.StartBlock, ix.checkpoint.Block)
+}
+
+// queryVersion returns the handler's QueryVersion, or 0 if its query never
+// changes.
+func (ix *Indexer) queryVersion() int {
+	if tracker, ok := ix.handler.(QueryTracker); ok {
+		return tracker.QueryVersion()
+	}
+	return 0
+}
+
+func (ix *Indexer) subscribeLogs(ctx context.Context, logs chan types.Log) (ethereum.Subscription, error) {
+	ix.subscribed = ix.queryVersion()
+	sub, err := ix.source.SubscribeFilterLogs(ctx, ix.handler.Query(), logs)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe to event logs: %w", err)
+	}
+	return sub, nil
+}
+
+// resubscribe replaces sub with a subscription for the handler's current
+// query and backfills from the checkpoint to the head, which picks up the
+// logs of newly watched contracts that sub didn't carry. Logs still queued
+// from sub are dropped; they are at or below the head and so backfilled.
+func (ix *Indexer) resubscribe(ctx context.Context, sub ethereum.Subscription) (ethereum.Subscription, chan types.Log, error) {
+	logs := make(chan types.Log, 1024)
+	next, err := ix.subscribeLogs(ctx, logs)
+	if err != nil {
+		return sub, nil, err
+	}
+	sub.Unsubscribe()
+	head, err := ix.client.BlockNumber(ctx)
+	if err != nil {
+		return next, logs, fmt.Errorf("get head block: %w", err)
+	}
+	return next, logs, ix.backfill(ctx, ix.resumeBlock(), head)
+}
+
+// notifyHead passes the latest head to the handler if it wants it.
+func (ix *Indexer) notifyHead(ctx context.Context, latest uint64) error {
+	if observer, ok := ix.handler.(HeadObserver); ok {
+		return observer.OnHead(ctx, latest)
+	}
+	return nil
+}
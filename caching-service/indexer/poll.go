@@ -0,0 +1,147 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Source delivers live logs and chain heads to an indexer. A Client is a
+// Source through WebSocket subscriptions; Poller is one for endpoints that
+// only speak HTTP.
+type Source interface {
+	SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// Client is the part of the node API the indexers use. *ethclient.Client
+// and *rpcpool.Pool implement it.
+type Client interface {
+	Source
+	bind.ContractCaller
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// Poller emulates log and head subscriptions by polling eth_blockNumber and
+// eth_getLogs. It never reports removed logs; reorgs are caught from the
+// heads it delivers instead.
+type Poller struct {
+	client    Client
+	interval  time.Duration
+	batchSize uint64
+}
+
+// NewPoller returns a Poller that checks for new blocks every interval.
+func NewPoller(client Client, interval time.Duration) *Poller {
+	return &Poller{client: client, interval: interval, batchSize: DefaultBatchSize}
+}
+
+// SubscribeFilterLogs delivers the logs matching q from blocks after the
+// current head.
+func (p *Poller) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	head, err := p.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get head block: %w", err)
+	}
+	next := head + 1
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		return p.poll(ctx, quit, func() error {
+			head, err := p.client.BlockNumber(ctx)
+			if err != nil {
+				return fmt.Errorf("get head block: %w", err)
+			}
+			if head+1 < next {
+				// The node was most likely restarted on a fresh chain. Failing
+				// makes the indexer start over from its checkpoint, which
+				// rolls back whatever is no longer canonical.
+				return fmt.Errorf("chain head moved back from block %d to %d", next-1, head)
+			}
+			for next <= head {
+				end := min(next+p.batchSize-1, head)
+				q.FromBlock = new(big.Int).SetUint64(next)
+				q.ToBlock = new(big.Int).SetUint64(end)
+				logs, err := p.client.FilterLogs(ctx, q)
+				if err != nil {
+					return fmt.Errorf("filter logs %d-%d: %w", next, end, err)
+				}
+				for _, vLog := range logs {
+					select {
+					case ch <- vLog:
+					case <-quit:
+						return nil
+					}
+				}
+				next = end + 1
+			}
+			return nil
+		})
+	}), nil
+}
+
+// SubscribeNewHead delivers every new head. Heights skipped between polls
+// are fetched too, up to headerWindow of them, so parent hashes can be
+// checked block by block.
+func (p *Poller) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	last, err := p.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get head block: %w", err)
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		return p.poll(ctx, quit, func() error {
+			head, err := p.client.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return fmt.Errorf("get head block: %w", err)
+			}
+			if head.Hash() == last.Hash() {
+				return nil
+			}
+			var headers []*types.Header
+			if n := head.Number.Uint64(); n > last.Number.Uint64() {
+				for i := max(last.Number.Uint64()+1, n-min(n, headerWindow-1)); i < n; i++ {
+					hdr, err := p.client.HeaderByNumber(ctx, new(big.Int).SetUint64(i))
+					if err != nil {
+						return fmt.Errorf("get header %d: %w", i, err)
+					}
+					headers = append(headers, hdr)
+				}
+			}
+			headers = append(headers, head)
+			for _, hdr := range headers {
+				select {
+				case ch <- hdr:
+				case <-quit:
+					return nil
+				}
+			}
+			last = head
+			return nil
+		})
+	}), nil
+}
+
+// poll calls fn every interval until it fails or the subscription ends.
+func (p *Poller) poll(ctx context.Context, quit <-chan struct{}, fn func() error) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-quit:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// headChain is a fakeChain whose head is set by the test, so blocks and
+// logs past it can be set up in advance.
+type headChain struct {
+	*fakeChain
+	head atomic.Uint64
+}
+
+func newHeadChain(blocks, head uint64) *headChain {
+	c := &headChain{fakeChain: newFakeChain(blocks)}
+	c.head.Store(head)
+	return c
+}
+
+func (c *headChain) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.head.Load(), nil
+}
+
+func (c *headChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		number = new(big.Int).SetUint64(c.head.Load())
+	}
+	return c.fakeChain.HeaderByNumber(ctx, number)
+}
+
+func newTestPoller(c Client) *Poller {
+	p := NewPoller(c, time.Millisecond)
+	p.batchSize = 3
+	return p
+}
+
+func TestPollerLogs(t *testing.T) {
+	chain := newHeadChain(30, 10)
+	contract := common.HexToAddress("0xc0")
+	for _, n := range []uint64{9, 12, 15, 19} {
+		chain.addLog(contract, n, 0)
+	}
+	chain.addLog(common.HexToAddress("0x0dd"), 13, 0)
+
+	logs := make(chan types.Log)
+	sub, err := newTestPoller(chain).SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{Addresses: []common.Address{contract}}, logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	// Logs up to the head at subscription time aren't delivered; the rest
+	// are, in order, read three blocks at a time.
+	chain.head.Store(20)
+	for _, want := range []uint64{12, 15, 19} {
+		select {
+		case vLog := <-logs:
+			if vLog.BlockNumber != want || vLog.Address != contract {
+				t.Errorf("got log from %s in block %d, want block %d", vLog.Address, vLog.BlockNumber, want)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no log from block %d", want)
+		}
+	}
+	want := [][2]uint64{{11, 13}, {14, 16}, {17, 19}, {20, 20}}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(chain.queried()) < len(want) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	queries := chain.queried()
+	if len(queries) != len(want) {
+		t.Fatalf("got %d queries, want %d", len(queries), len(want))
+	}
+	for i, q := range queries {
+		if got := [2]uint64{q.FromBlock.Uint64(), q.ToBlock.Uint64()}; got != want[i] {
+			t.Errorf("query %d covers blocks %v, want %v", i, got, want[i])
+		}
+	}
+
+	// A head below what was already read fails the subscription.
+	chain.head.Store(5)
+	select {
+	case err := <-sub.Err():
+		if err == nil || !strings.Contains(err.Error(), "chain head moved back from block 20 to 5") {
+			t.Errorf("subscription failed with %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription didn't fail after the head moved back")
+	}
+}
+
+func TestPollerHeads(t *testing.T) {
+	chain := newHeadChain(200, 10)
+	heads := make(chan *types.Header)
+	sub, err := newTestPoller(chain).SubscribeNewHead(context.Background(), heads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	expect := func(from, to uint64) {
+		t.Helper()
+		for n := from; n <= to; n++ {
+			select {
+			case hdr := <-heads:
+				if hdr.Hash() != chain.header(n).Hash() {
+					t.Fatalf("got header %d, want %d", hdr.Number, n)
+				}
+			case err := <-sub.Err():
+				t.Fatalf("subscription failed: %v", err)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("no header %d", n)
+			}
+		}
+	}
+	// Heights skipped between polls are delivered too.
+	chain.head.Store(13)
+	expect(11, 13)
+	chain.head.Store(14)
+	expect(14, 14)
+	// After a longer gap only the last headerWindow heights are.
+	chain.head.Store(14 + headerWindow + 20)
+	expect(14+21, 14+headerWindow+20)
+
+	select {
+	case hdr := <-heads:
+		t.Errorf("got header %d after the head stopped moving", hdr.Number)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
@@ -0,0 +1,127 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/userprofile"
+)
+
+// Profiles indexes UserProfile into profiles, with the raw events kept in
+// profile_events so the table can be rebuilt after a reorg.
+//
+// deleteProfile only clears isActive on chain, so deleted profiles keep
+// their last username, bio and image hash with is_active = false.
+type Profiles struct {
+	chainID  int64
+	contract common.Address
+	decoder  *decoder
+}
+
+// NewProfiles creates the handler for the UserProfile contract at contract
+// on chainID.
+func NewProfiles(chainID int64, contract common.Address) (*Profiles, error) {
+	filterer, err := userprofile.NewBindingsFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bind user profile: %w", err)
+	}
+	dec := newDecoder()
+	if err := dec.register(userprofile.BindingsMetaData, map[string]parseFunc{
+		"ProfileUpdated": parser(filterer.ParseProfileUpdated),
+		"ProfileDeleted": parser(filterer.ParseProfileDeleted),
+	}); err != nil {
+		return nil, err
+	}
+	return &Profiles{chainID: chainID, contract: contract, decoder: dec}, nil
+}
+
+func (p *Profiles) Name() string { return fmt.Sprintf("profiles@%d", p.chainID) }
+
+func (p *Profiles) Contract() common.Address { return p.contract }
+
+func (p *Profiles) Query() ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{p.contract},
+		Topics:    [][]common.Hash{p.decoder.topics()},
+	}
+}
+
+// Load is a no-op; the handler keeps no state outside the store.
+func (p *Profiles) Load(ctx context.Context) error { return nil }
+
+func (p *Profiles) HandleLog(ctx context.Context, tx store.Tx, vLog types.Log) (bool, error) {
+	if vLog.Address != p.contract {
+		return false, nil
+	}
+	event, err := p.decoder.decode(vLog)
+	if errors.Is(err, errUnknownEvent) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("decode log: %w", err)
+	}
+
+	switch e := event.(type) {
+	case *userprofile.BindingsProfileUpdated:
+		return true, p.handleProfileUpdated(ctx, tx, e)
+	case *userprofile.BindingsProfileDeleted:
+		return true, p.handleProfileDeleted(ctx, tx, e)
+	default:
+		return false, nil
+	}
+}
+
+func (p *Profiles) handleProfileUpdated(ctx context.Context, tx store.Tx, event *userprofile.BindingsProfileUpdated) error {
+	vLog := event.Raw
+	inserted, err := tx.ApplyProfileEvent(ctx, store.ProfileEvent{
+		ChainID:          p.chainID,
+		Name:             "ProfileUpdated",
+		Address:          event.User,
+		Username:         event.Username,
+		Bio:              event.Bio,
+		ProfileImageHash: event.ProfileImageHash,
+	}, vLog)
+	if err != nil {
+		return err
+	}
+	if !inserted {
+		skipApplied("ProfileUpdated", vLog)
+		return nil
+	}
+	fmt.Printf("📝 Profile %s updated for %s (block %d)\n", event.Username, event.User.Hex(), vLog.BlockNumber)
+	return nil
+}
+
+// handleProfileDeleted deactivates the profile, creating it if the address
+// never set one.
+func (p *Profiles) handleProfileDeleted(ctx context.Context, tx store.Tx, event *userprofile.BindingsProfileDeleted) error {
+	vLog := event.Raw
+	inserted, err := tx.ApplyProfileEvent(ctx, store.ProfileEvent{
+		ChainID: p.chainID,
+		Name:    "ProfileDeleted",
+		Address: event.User,
+	}, vLog)
+	if err != nil {
+		return err
+	}
+	if !inserted {
+		skipApplied("ProfileDeleted", vLog)
+		return nil
+	}
+	fmt.Printf("📝 Profile deleted for %s (block %d)\n", event.User.Hex(), vLog.BlockNumber)
+	return nil
+}
+
+// Rollback rebuilds every profile touched by events from block `from`
+// onwards out of the events that remain, then deletes those events.
+func (p *Profiles) Rollback(ctx context.Context, tx store.Tx, from uint64) error {
+	if err := tx.RollbackProfiles(ctx, p.chainID, from); err != nil {
+		return fmt.Errorf("roll back profiles: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// headerWindow is how many recent canonical block hashes are kept in memory
+// for parent-hash checks. Reorgs deeper than this are only caught through the
+// hashes stored alongside ingested rows.
+const headerWindow = 128
+
+// headerCache remembers the canonical hash seen for each recent block height.
+type headerCache map[uint64]common.Hash
+
+func (c headerCache) add(h *types.Header) {
+	n := h.Number.Uint64()
+	c[n] = h.Hash()
+	for k := range c {
+		// Heights above n belonged to a chain that has just been replaced.
+		if k > n || k+headerWindow <= n {
+			delete(c, k)
+		}
+	}
+}
+
+// onHead checks a new chain head against the recorded hashes and, if the
+// chain was reorganized, rolls back and re-ingests everything after the
+// common ancestor. The handler is then told about the new head.
+func (ix *Indexer) onHead(ctx context.Context, head *types.Header) error {
+	n := head.Number.Uint64()
+	reorged := false
+	if known, ok := ix.headers[n]; ok && known != head.Hash() {
+		reorged = true
+	}
+	if known, ok := ix.headers[n-1]; n > 0 && ok && known != head.ParentHash {
+		reorged = true
+	}
+	if reorged {
+		from, err := ix.forkStart(ctx, n-1)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🔀 [%s] Chain reorganization detected at block %d, rolling back to block %d\n", ix.handler.Name(), n, from)
+		if err := ix.reorg(ctx, from); err != nil {
+			return err
+		}
+	}
+	ix.headers.add(head)
+	return ix.notifyHead(ctx, n)
+}
+
+// forkStart walks down from block n until the canonical hash matches the one
+// recorded in the header cache, and returns the block after that common
+// ancestor. Below the cache, for reorgs deeper than headerWindow or right
+// after a restart, it goes by the hashes stored with ingested logs instead.
+// If every cached block was replaced, it returns the start block.
+func (ix *Indexer) forkStart(ctx context.Context, n uint64) (uint64, error) {
+	for ; n > 0; n-- {
+		known, ok := ix.headers[n]
+		if !ok {
+			return ix.rollbackFrom(ctx, n+1)
+		}
+		hdr, err := ix.client.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return 0, fmt.Errorf("get header %d: %w", n, err)
+		}
+		if hdr.Hash() == known {
+			return n + 1, nil
+		}
+	}
+	return max(1, ix.cfg.StartBlock), nil
+}
+
+// rollbackFrom returns the block after the newest block below `before` that
+// logs were ingested from and that is still canonical, or the start block if
+// there is none. Blocks without ingested logs have nothing to roll back.
+func (ix *Indexer) rollbackFrom(ctx context.Context, before uint64) (uint64, error) {
+	blocks, err := ix.store.IngestedBlocks(ctx, ix.chainID, ix.handler.Contract(), before)
+	if err != nil {
+		return 0, fmt.Errorf("load ingested blocks: %w", err)
+	}
+	for _, b := range blocks {
+		ok, err := ix.isCanonical(ctx, b.Number, b.Hash)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return b.Number + 1, nil
+		}
+	}
+	return ix.cfg.StartBlock, nil
+}
+
+// onRemovedLogs handles logs the node has retracted because their blocks
+// left the canonical chain. A reorg retracts its logs one by one, so the
+// ones already queued in logs are taken along and everything is rolled back
+// once, from the lowest ingested block among them. Logs from blocks that
+// were never ingested are ignored. The queued logs that weren't removed are
+// returned for the caller to handle after the rollback.
+func (ix *Indexer) onRemovedLogs(ctx context.Context, removed types.Log, logs <-chan types.Log) ([]types.Log, error) {
+	batch := []types.Log{removed}
+	var rest []types.Log
+queued:
+	for {
+		select {
+		case vLog := <-logs:
+			if vLog.Removed {
+				batch = append(batch, vLog)
+			} else {
+				rest = append(rest, vLog)
+			}
+		default:
+			break queued
+		}
+	}
+
+	var from *types.Log
+	for i, vLog := range batch {
+		if from != nil && vLog.BlockNumber >= from.BlockNumber {
+			continue
+		}
+		ingested, err := ix.store.IngestedBlock(ctx, ix.chainID, ix.handler.Contract(), vLog.BlockHash)
+		if err != nil {
+			return nil, fmt.Errorf("look up removed block: %w", err)
+		}
+		if ingested {
+			from = &batch[i]
+		}
+	}
+	if from == nil {
+		return rest, nil
+	}
+	fmt.Printf("🔀 [%s] %d log(s) from block %d (%s) onwards were removed by a reorg\n", ix.handler.Name(), len(batch), from.BlockNumber, from.BlockHash.Hex())
+	return rest, ix.reorg(ctx, from.BlockNumber)
+}
+
+// verifyCheckpoint makes sure the checkpoint block is still canonical. If the
+// chain reorganized while the service was down, rows are rolled back to the
+// newest ingested block that is still canonical.
+func (ix *Indexer) verifyCheckpoint(ctx context.Context) error {
+	if ix.checkpoint == nil {
+		return nil
+	}
+	canonical, err := ix.isCanonical(ctx, ix.checkpoint.Block, ix.checkpoint.BlockHash)
+	if err != nil || canonical {
+		return err
+	}
+
+	from, err := ix.rollbackFrom(ctx, ix.checkpoint.Block)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔀 [%s] Checkpoint block %d is no longer canonical, rolling back to block %d\n", ix.handler.Name(), ix.checkpoint.Block, from)
+	return ix.rollback(ctx, from)
+}
+
+func (ix *Indexer) isCanonical(ctx context.Context, n uint64, hash common.Hash) (bool, error) {
+	hdr, err := ix.client.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+	if err != nil {
+		return false, fmt.Errorf("get header %d: %w", n, err)
+	}
+	return hdr.Hash() == hash, nil
+}
+
+// reorg rolls back everything from block `from` onwards and re-ingests the
+// canonical logs from there up to the node's current head.
+func (ix *Indexer) reorg(ctx context.Context, from uint64) error {
+	if err := ix.rollback(ctx, from); err != nil {
+		return err
+	}
+	head, err := ix.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("get head block: %w", err)
+	}
+	return ix.backfill(ctx, from, head)
+}
+
+// rollback has the handler delete everything it derived from block `from`
+// onwards and moves the checkpoint back to the newest log that remains.
+func (ix *Indexer) rollback(ctx context.Context, from uint64) error {
+	tx, err := ix.store.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin rollback: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := ix.handler.Rollback(ctx, tx, from); err != nil {
+		return err
+	}
+
+	contract := ix.handler.Contract()
+	deleted, err := tx.DeleteLogs(ctx, ix.chainID, contract, from)
+	if err != nil {
+		return fmt.Errorf("delete indexed logs: %w", err)
+	}
+	cp, err := tx.LastLog(ctx, ix.chainID, contract)
+	if err != nil {
+		return fmt.Errorf("find last indexed log: %w", err)
+	}
+	if cp == nil {
+		err = tx.DeleteCheckpoint(ctx, ix.chainID, contract)
+	} else {
+		err = tx.SaveCheckpoint(ctx, ix.chainID, contract, *cp)
+	}
+	if err != nil {
+		return fmt.Errorf("move checkpoint: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit rollback: %w", err)
+	}
+
+	ix.checkpoint = cp
+	log.Printf("[%s] Rolled back %d log(s) from block %d onwards", ix.handler.Name(), deleted, from)
+	return ix.handler.Load(ctx)
+}
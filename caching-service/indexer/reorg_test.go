@@ -0,0 +1,149 @@
+package indexer
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// followed returns an indexer that has backfilled chain and seen every head
+// from `from` to the chain's head.
+func followed(t *testing.T, chain *fakeChain, h *testHandler, from uint64) *Indexer {
+	t.Helper()
+	ix := newTestIndexer(chain, h)
+	head, _ := chain.BlockNumber(context.Background())
+	if err := ix.backfill(context.Background(), 0, head); err != nil {
+		t.Fatal(err)
+	}
+	for n := from; n <= head; n++ {
+		ix.headers.add(chain.header(n))
+	}
+	return ix
+}
+
+func checkApplied(t *testing.T, chain *fakeChain, h *testHandler, want ...uint64) {
+	t.Helper()
+	if got := h.appliedBlocks(); !slices.Equal(got, want) {
+		t.Fatalf("applied logs from blocks %v, want %v", got, want)
+	}
+	for _, l := range h.applied {
+		if l.BlockHash != chain.header(l.BlockNumber).Hash() {
+			t.Errorf("log from block %d is from a block that is no longer canonical", l.BlockNumber)
+		}
+	}
+}
+
+func TestOnHeadShallowReorg(t *testing.T) {
+	chain := newFakeChain(10)
+	h := newTestHandler()
+	chain.addLog(h.factory, 8, 0)
+	chain.addLog(h.factory, 9, 0)
+	ix := followed(t, chain, h, 0)
+
+	chain.extend(9, 11, 1)
+	chain.addLog(h.factory, 9, 0)
+	if err := ix.onHead(context.Background(), chain.header(11)); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(h.rollbacks, []uint64{9}) {
+		t.Errorf("rollbacks = %v, want [9]", h.rollbacks)
+	}
+	checkApplied(t, chain, h, 8, 9)
+	if ix.checkpoint == nil || ix.checkpoint.BlockHash != chain.header(9).Hash() {
+		t.Errorf("checkpoint = %+v, want the new block 9", ix.checkpoint)
+	}
+}
+
+// A reorg reaching below the header cache, as right after a restart, is
+// traced through the hashes of the ingested blocks.
+func TestOnHeadReorgBelowHeaderCache(t *testing.T) {
+	chain := newFakeChain(10)
+	h := newTestHandler()
+	chain.addLog(h.factory, 3, 0)
+	chain.addLog(h.factory, 5, 0)
+	chain.addLog(h.factory, 9, 0)
+	ix := followed(t, chain, h, 8)
+
+	chain.extend(4, 11, 1)
+	chain.addLog(h.factory, 6, 0)
+	if err := ix.onHead(context.Background(), chain.header(11)); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(h.rollbacks, []uint64{4}) {
+		t.Errorf("rollbacks = %v, want [4]", h.rollbacks)
+	}
+	checkApplied(t, chain, h, 3, 6)
+}
+
+// A reorg replacing every cached block goes back to the start block, not
+// to genesis.
+func TestForkStartBelowStartBlock(t *testing.T) {
+	chain := newFakeChain(10)
+	h := newTestHandler()
+	ix := followed(t, chain, h, 0)
+	ix.cfg.StartBlock = 5
+
+	chain.extend(1, 10, 1)
+	if from, err := ix.forkStart(context.Background(), 10); err != nil || from != 5 {
+		t.Errorf("forkStart = %d, %v; want 5", from, err)
+	}
+}
+
+func TestVerifyCheckpointAfterRestart(t *testing.T) {
+	chain := newFakeChain(10)
+	h := newTestHandler()
+	chain.addLog(h.factory, 4, 0)
+	chain.addLog(h.factory, 8, 0)
+	ix := newTestIndexer(chain, h)
+	if err := ix.backfill(context.Background(), 0, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	chain.extend(6, 12, 1)
+	if err := ix.verifyCheckpoint(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(h.rollbacks, []uint64{5}) {
+		t.Errorf("rollbacks = %v, want [5]", h.rollbacks)
+	}
+	checkApplied(t, chain, h, 4)
+	if ix.checkpoint == nil || ix.checkpoint.Block != 4 {
+		t.Errorf("checkpoint = %+v, want block 4", ix.checkpoint)
+	}
+}
+
+// The node retracts a reorg's logs newest first; they are rolled back
+// together from the lowest one.
+func TestRemovedLogsRollBackOnce(t *testing.T) {
+	chain := newFakeChain(10)
+	h := newTestHandler()
+	removed := []types.Log{
+		chain.addLog(h.factory, 7, 0),
+		chain.addLog(h.factory, 6, 1),
+		chain.addLog(h.factory, 6, 0),
+	}
+	chain.addLog(h.factory, 5, 0)
+	ix := followed(t, chain, h, 0)
+
+	chain.extend(6, 10, 1)
+	replacement := chain.addLog(h.factory, 8, 0)
+	logs := make(chan types.Log, 8)
+	for _, l := range removed[1:] {
+		l.Removed = true
+		logs <- l
+	}
+	logs <- replacement
+	removed[0].Removed = true
+	if err := ix.onLog(context.Background(), removed[0], logs); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(h.rollbacks, []uint64{6}) {
+		t.Errorf("rollbacks = %v, want [6]", h.rollbacks)
+	}
+	checkApplied(t, chain, h, 5, 8)
+	if len(logs) != 0 {
+		t.Errorf("%d logs left queued", len(logs))
+	}
+}
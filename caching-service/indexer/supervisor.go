@@ -0,0 +1,127 @@
+package indexer
+
+import (
+	"context"
+	"log"
+	"maps"
+	"sync"
+	"time"
+)
+
+// Reconnect backoff bounds. A session that stayed up for at least
+// maxBackoff resets the delay, so a node restart hours later starts again
+// from minBackoff.
+const (
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+)
+
+// clock is the time source of the reconnect loop; tests replace it.
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Connection states reported through Health.
+const (
+	StateConnecting   = "connecting"
+	StateBackfilling  = "backfilling"
+	StateLive         = "live"
+	StateReconnecting = "reconnecting"
+)
+
+// State is one indexer's connection state.
+type State struct {
+	State      string    `json:"state"`
+	Since      time.Time `json:"since"`
+	Reconnects int       `json:"reconnects"`
+	LastError  string    `json:"lastError,omitempty"`
+}
+
+// Health collects the connection state of every indexer sharing it, for
+// health checks. A nil *Health discards updates.
+type Health struct {
+	mu     sync.Mutex
+	states map[string]State
+}
+
+// NewHealth returns an empty Health.
+func NewHealth() *Health {
+	return &Health{states: make(map[string]State)}
+}
+
+// States returns a snapshot of every indexer's state, keyed by name.
+func (h *Health) States() map[string]State {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return maps.Clone(h.states)
+}
+
+// Live reports whether every indexer is following the chain head.
+func (h *Health) Live() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, s := range h.states {
+		if s.State != StateLive {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *Health) set(name, state string, err error) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s := h.states[name]
+	if s.State != state {
+		s.Since = time.Now()
+	}
+	if state == StateReconnecting {
+		s.Reconnects++
+	}
+	if err != nil {
+		s.LastError = err.Error()
+	}
+	s.State = state
+	h.states[name] = s
+}
+
+// Run indexes until ctx is cancelled. When a session fails, typically
+// because the node went away, it waits with exponential backoff and starts
+// a new one, which backfills from the checkpoint over the gap and
+// resubscribes. The RPC client redials on its own once it is used again.
+func (ix *Indexer) Run(ctx context.Context) error {
+	backoff := minBackoff
+	for {
+		ix.setState(StateConnecting, nil)
+		started := ix.clock.Now()
+		err := ix.session(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if ix.clock.Now().Sub(started) >= maxBackoff {
+			backoff = minBackoff
+		}
+		ix.setState(StateReconnecting, err)
+		log.Printf("[%s] Indexer stopped: %v; reconnecting in %s", ix.handler.Name(), err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ix.clock.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+func (ix *Indexer) setState(state string, err error) {
+	ix.cfg.Health.set(ix.handler.Name(), state, err)
+}
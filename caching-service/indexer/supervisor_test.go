@@ -0,0 +1,159 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+// fakeClock returns from every wait at once, moving its time forward by the
+// wait.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+	// onWait, if set, is called at the start of every wait.
+	onWait func()
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	if c.onWait != nil {
+		c.onWait()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+	fired := make(chan time.Time, 1)
+	fired <- c.now
+	return fired
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// hookedChain calls onChainID at the start of every session, failing the
+// session if it returns an error, and onFilterLogs on every log query.
+type hookedChain struct {
+	*fakeChain
+	onChainID    func() error
+	onFilterLogs func()
+}
+
+func (c hookedChain) ChainID(ctx context.Context) (*big.Int, error) {
+	if err := c.onChainID(); err != nil {
+		return nil, err
+	}
+	return c.fakeChain.ChainID(ctx)
+}
+
+func (c hookedChain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if c.onFilterLogs != nil {
+		c.onFilterLogs()
+	}
+	return c.fakeChain.FilterLogs(ctx, q)
+}
+
+var errNodeDown = errors.New("node is down")
+
+func TestRunBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	sessions := 0
+	chain := hookedChain{fakeChain: newFakeChain(10), onChainID: func() error {
+		sessions++
+		switch sessions {
+		case 5:
+			// This session stays up long enough to reset the backoff.
+			clock.advance(maxBackoff)
+		case 13:
+			cancel()
+		}
+		return errNodeDown
+	}}
+	ix := New(chain, store.NewMemory(), newTestHandler(), Config{})
+	ix.clock = clock
+
+	if err := ix.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run returned %v", err)
+	}
+	var want []time.Duration
+	for _, s := range []int{1, 2, 4, 8, 1, 2, 4, 8, 16, 32, 60, 60} {
+		want = append(want, time.Duration(s)*time.Second)
+	}
+	if !slices.Equal(clock.waits, want) {
+		t.Errorf("waited %v, want %v", clock.waits, want)
+	}
+}
+
+func TestRunHealth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	health := NewHealth()
+	h := newTestHandler()
+
+	// states records each state the indexer is seen in, from its own
+	// goroutine.
+	var states []string
+	record := func() {
+		state := health.States()[h.Name()].State
+		if len(states) == 0 || states[len(states)-1] != state {
+			states = append(states, state)
+		}
+	}
+	sessions := 0
+	chain := hookedChain{
+		fakeChain: newFakeChain(10),
+		onChainID: func() error {
+			record()
+			if sessions++; sessions <= 2 {
+				return errNodeDown
+			}
+			return nil
+		},
+		onFilterLogs: record,
+	}
+	ix := New(chain, store.NewMemory(), h, Config{Health: health})
+	ix.clock = &fakeClock{onWait: record}
+
+	done := make(chan error, 1)
+	go func() { done <- ix.Run(ctx) }()
+	deadline := time.Now().Add(5 * time.Second)
+	for health.States()[h.Name()].State != StateLive {
+		if time.Now().After(deadline) {
+			t.Fatalf("indexer never went live; state %+v", health.States()[h.Name()])
+		}
+		time.Sleep(time.Millisecond)
+	}
+	final := health.States()[h.Name()]
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run returned %v", err)
+	}
+
+	want := []string{StateConnecting, StateReconnecting, StateConnecting, StateReconnecting, StateConnecting, StateBackfilling}
+	if !slices.Equal(states, want) {
+		t.Errorf("went through states %v, want %v", states, want)
+	}
+	if final.Reconnects != 2 || final.LastError != "get chain ID: node is down" || !health.Live() {
+		t.Errorf("state = %+v, want live after 2 reconnects", final)
+	}
+}
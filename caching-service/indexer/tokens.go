@@ -0,0 +1,260 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/socialtoken"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+	"golang.org/x/sync/errgroup"
+)
+
+// tokenDiscoveryInterval is how often Tokens looks for escrow tokens it
+// isn't indexing yet.
+const tokenDiscoveryInterval = 15 * time.Second
+
+// Token indexes the Transfer and Approval events of one ERC-20 contract
+// into token_transfers and token_approvals and keeps token_balances current.
+//
+// Balances are only correct if the token is indexed from its deployment
+// block, which is where Tokens starts it.
+type Token struct {
+	chainID int64
+	token   common.Address
+	decoder *decoder
+}
+
+// NewToken creates the handler for the ERC-20 token at token on chainID.
+// The SocialToken binding is used for decoding, which works for any ERC-20.
+func NewToken(chainID int64, token common.Address) (*Token, error) {
+	filterer, err := socialtoken.NewBindingsFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bind token: %w", err)
+	}
+	dec := newDecoder()
+	if err := dec.register(socialtoken.BindingsMetaData, map[string]parseFunc{
+		"Transfer": parser(filterer.ParseTransfer),
+		"Approval": parser(filterer.ParseApproval),
+	}); err != nil {
+		return nil, err
+	}
+	return &Token{chainID: chainID, token: token, decoder: dec}, nil
+}
+
+func (t *Token) Name() string { return fmt.Sprintf("token %s@%d", t.token.Hex()[:10], t.chainID) }
+
+func (t *Token) Contract() common.Address { return t.token }
+
+func (t *Token) Query() ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{t.token},
+		Topics:    [][]common.Hash{t.decoder.topics()},
+	}
+}
+
+// Load is a no-op; the handler keeps no state outside the store.
+func (t *Token) Load(ctx context.Context) error { return nil }
+
+func (t *Token) HandleLog(ctx context.Context, tx store.Tx, vLog types.Log) (bool, error) {
+	if vLog.Address != t.token {
+		return false, nil
+	}
+	event, err := t.decoder.decode(vLog)
+	if errors.Is(err, errUnknownEvent) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("decode log: %w", err)
+	}
+
+	switch e := event.(type) {
+	case *socialtoken.BindingsTransfer:
+		return true, t.handleTransfer(ctx, tx, e)
+	case *socialtoken.BindingsApproval:
+		// A replayed approval is already stored and needs nothing more.
+		_, err := tx.InsertApproval(ctx, store.Approval{
+			ChainID: t.chainID,
+			Token:   t.token,
+			Owner:   e.Owner,
+			Spender: e.Spender,
+			Amount:  e.Value,
+		}, vLog)
+		if err != nil {
+			return true, fmt.Errorf("insert approval: %w", err)
+		}
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// handleTransfer records the transfer and moves the amount between the two
+// balances. A replayed transfer leaves them alone.
+func (t *Token) handleTransfer(ctx context.Context, tx store.Tx, event *socialtoken.BindingsTransfer) error {
+	_, err := tx.ApplyTransfer(ctx, store.Transfer{
+		ChainID: t.chainID,
+		Token:   t.token,
+		From:    event.From,
+		To:      event.To,
+		Amount:  event.Value,
+	}, event.Raw)
+	if err != nil {
+		return fmt.Errorf("apply transfer: %w", err)
+	}
+	return nil
+}
+
+// Rollback reverses the balance changes of transfers from block `from`
+// onwards and deletes those transfers and approvals.
+func (t *Token) Rollback(ctx context.Context, tx store.Tx, from uint64) error {
+	if err := tx.RollbackToken(ctx, t.chainID, t.token, from); err != nil {
+		return fmt.Errorf("roll back token: %w", err)
+	}
+	return nil
+}
+
+// Tokens runs a Token indexer for each configured token and for every token
+// an escrow in deals uses, starting new ones as new tokens show up.
+//
+// Each token is indexed from its deployment block rather than START_BLOCK,
+// since a later start would leave out earlier transfers and so get the
+// balances wrong. Finding that block reads historical contract code, which
+// needs a node that keeps old state; without one a token starts at
+// StartBlock and its balances may be incomplete.
+type Tokens struct {
+	client   Client
+	store    store.Store
+	chainID  int64
+	cfg      Config
+	tokens   []common.Address
+	running  map[common.Address]struct{}
+	interval time.Duration
+}
+
+// NewTokens creates a Tokens runner for chainID. The given tokens are
+// indexed even if no escrow uses them.
+func NewTokens(client Client, st store.Store, chainID int64, cfg Config, tokens ...common.Address) *Tokens {
+	return &Tokens{
+		client:   client,
+		store:    st,
+		chainID:  chainID,
+		cfg:      cfg,
+		tokens:   tokens,
+		running:  make(map[common.Address]struct{}),
+		interval: tokenDiscoveryInterval,
+	}
+}
+
+// Run starts the token indexers and keeps discovering escrow tokens until
+// ctx is cancelled. A token that can't be started, or a failure to list the
+// escrow tokens, is logged and retried on the next round.
+func (t *Tokens) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+		for {
+			tokens, err := t.store.EscrowTokens(ctx, t.chainID)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("[tokens@%d] Failed to load escrow tokens: %v; retrying in %s", t.chainID, err, t.interval)
+			}
+			for _, token := range append(slices.Clone(t.tokens), tokens...) {
+				if _, ok := t.running[token]; ok || token == (common.Address{}) {
+					continue
+				}
+				ix, err := t.indexer(ctx, token)
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Printf("[tokens@%d] Failed to start token %s: %v; retrying in %s", t.chainID, token.Hex(), err, t.interval)
+					}
+					continue
+				}
+				t.running[token] = struct{}{}
+				g.Go(func() error { return ix.Run(ctx) })
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+	})
+	return g.Wait()
+}
+
+// indexer creates the indexer for token, starting at its deployment block
+// unless it already has a checkpoint to resume from, or at StartBlock if the
+// node can't tell when the token was deployed.
+func (t *Tokens) indexer(ctx context.Context, token common.Address) (*Indexer, error) {
+	handler, err := NewToken(t.chainID, token)
+	if err != nil {
+		return nil, err
+	}
+	cfg := t.cfg
+	cp, err := t.store.Checkpoint(ctx, t.chainID, token)
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint: %w", err)
+	}
+	if cp == nil {
+		deployed, err := deploymentBlock(ctx, t.client, token)
+		switch {
+		case errors.Is(err, errNoHistory):
+			log.Printf("[tokens@%d] Can't find the deployment block of token %s (%v); indexing it from block %d, so its balances may be incomplete", t.chainID, token.Hex(), err, cfg.StartBlock)
+		case err != nil:
+			return nil, err
+		default:
+			cfg.StartBlock = deployed
+		}
+	}
+	return New(t.client, t.store, handler, cfg), nil
+}
+
+// errNoHistory is returned by deploymentBlock when the node can read the
+// contract's code at the head but not at older blocks, as with nodes that
+// prune old state.
+var errNoHistory = errors.New("node has no historical state")
+
+// deploymentBlock finds the block contract was deployed in by binary search
+// over the blocks it has code at.
+func deploymentBlock(ctx context.Context, client Client, contract common.Address) (uint64, error) {
+	hasCode := func(n uint64) (bool, error) {
+		code, err := client.CodeAt(ctx, contract, new(big.Int).SetUint64(n))
+		if err != nil {
+			return false, fmt.Errorf("read code at block %d: %w", n, err)
+		}
+		return len(code) > 0, nil
+	}
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get block number: %w", err)
+	}
+	ok, err := hasCode(head)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("no contract at %s", contract.Hex())
+	}
+	lo, hi := uint64(0), head
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		ok, err := hasCode(mid)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", errNoHistory, err)
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return hi, nil
+}
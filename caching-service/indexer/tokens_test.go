@@ -0,0 +1,95 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/store"
+)
+
+func TestDeploymentBlock(t *testing.T) {
+	chain := newFakeChain(100)
+	token := common.HexToAddress("0x70c")
+	for _, n := range []uint64{0, 37, 100} {
+		chain.deploy(token, n)
+		if got, err := deploymentBlock(context.Background(), chain, token); err != nil || got != n {
+			t.Errorf("deployed at %d: got %d, %v", n, got, err)
+		}
+	}
+	if _, err := deploymentBlock(context.Background(), chain, common.HexToAddress("0x0dd")); err == nil {
+		t.Error("found a deployment block for an address without code")
+	}
+	chain.prunedBelow = 90
+	if _, err := deploymentBlock(context.Background(), chain, token); !errors.Is(err, errNoHistory) {
+		t.Errorf("on a pruned node: got %v, want errNoHistory", err)
+	}
+}
+
+// Without historical state a token starts at StartBlock instead.
+func TestTokensPrunedNode(t *testing.T) {
+	chain := newFakeChain(100)
+	token := common.HexToAddress("0x70c")
+	chain.deploy(token, 40)
+	chain.prunedBelow = 90
+	tokens := NewTokens(chain, store.NewMemory(), testChainID, Config{StartBlock: 60, BatchSize: 100})
+	ix, err := tokens.indexer(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ix.cfg.StartBlock != 60 {
+		t.Errorf("token starts at block %d, want 60", ix.cfg.StartBlock)
+	}
+}
+
+// flakyStore fails EscrowTokens the first time and then reports token.
+type flakyStore struct {
+	store.Store
+	token common.Address
+	calls atomic.Int32
+}
+
+func (s *flakyStore) EscrowTokens(ctx context.Context, chainID int64) ([]common.Address, error) {
+	if s.calls.Add(1) == 1 {
+		return nil, errors.New("database is down")
+	}
+	return []common.Address{s.token}, nil
+}
+
+// A failure to list the escrow tokens is retried, and a discovered token is
+// indexed from its deployment block rather than StartBlock.
+func TestTokensRetryDiscovery(t *testing.T) {
+	chain := newFakeChain(100)
+	token := common.HexToAddress("0x70c")
+	chain.deploy(token, 40)
+	st := &flakyStore{Store: store.NewMemory(), token: token}
+	tokens := NewTokens(chain, st, testChainID, Config{StartBlock: 60, BatchSize: 100})
+	tokens.interval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- tokens.Run(ctx) }()
+	defer func() {
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("Run returned %v", err)
+		}
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, q := range chain.queried() {
+			if len(q.Addresses) == 1 && q.Addresses[0] == token {
+				if from := q.FromBlock.Uint64(); from != 40 {
+					t.Fatalf("token indexed from block %d, want 40", from)
+				}
+				return
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("token was never indexed; EscrowTokens called %d times", st.calls.Load())
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	_ "github.com/lib/pq"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/db/migrations"
+)
+
+const migrateUsage = "usage: caching-service migrate [flags] up | down [n] | status | force <version>"
+
+// runMigrate implements the migrate subcommand, which manages the schema
+// without starting the indexers.
+func runMigrate(databaseURL string, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	if !strings.HasPrefix(databaseURL, "postgres://") && !strings.HasPrefix(databaseURL, "postgresql://") {
+		return errors.New("migrate needs a Postgres DATABASE_URL; SQLite and in-memory stores create their schema when opened")
+	}
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return fmt.Errorf("open database connection: %w", err)
+	}
+	defer db.Close()
+	m, err := migrations.New(db)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch cmd, rest := args[0], args[1:]; {
+	case cmd == "up" && len(rest) == 0:
+		return m.Up(ctx)
+	case cmd == "down" && len(rest) <= 1:
+		n := 1
+		if len(rest) == 1 {
+			if n, err = strconv.Atoi(rest[0]); err != nil || n < 1 {
+				return fmt.Errorf("down: %q is not a positive number of migrations", rest[0])
+			}
+		}
+		return m.Down(ctx, n)
+	case cmd == "force" && len(rest) == 1:
+		version, err := strconv.ParseUint(rest[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("force: %q is not a version", rest[0])
+		}
+		return m.Force(ctx, version)
+	case cmd == "status" && len(rest) == 0:
+		st, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		switch {
+		case st.Version == 0:
+			fmt.Println("No migrations applied")
+		case st.Dirty:
+			fmt.Printf("Version %d (dirty)\n", st.Version)
+		default:
+			fmt.Printf("Version %d\n", st.Version)
+		}
+		for _, mig := range st.Pending {
+			fmt.Printf("  pending: %d_%s\n", mig.Version, mig.Name)
+		}
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
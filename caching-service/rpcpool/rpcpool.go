@@ -0,0 +1,368 @@
+// Package rpcpool spreads the caching service's node traffic over several
+// RPC endpoints. Requests go to the healthiest endpoint and fail over to the
+// next when it errors; in quorum mode the head block and eth_call results
+// are only trusted once several endpoints agree on them.
+package rpcpool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DefaultCheckInterval is used when Config.CheckInterval is zero.
+const DefaultCheckInterval = 10 * time.Second
+
+// Config controls failover and quorum.
+type Config struct {
+	// Quorum is how many endpoints must agree on the head block and on
+	// eth_call results. Zero or one trusts a single endpoint.
+	Quorum int
+	// MaxLag is how many blocks an endpoint may trail the best head before
+	// it is passed over.
+	MaxLag uint64
+	// CheckInterval is how often Run probes every endpoint.
+	CheckInterval time.Duration
+}
+
+// Pool is a set of RPC endpoints for one chain.
+type Pool struct {
+	cfg       Config
+	endpoints []*endpoint
+}
+
+type endpoint struct {
+	url    string
+	client *ethclient.Client
+
+	mu       sync.Mutex
+	head     uint64
+	failures int
+	latency  time.Duration
+	lagging  bool
+}
+
+// Dial connects to every URL. Endpoints that can't be dialled are an error,
+// since a typo would otherwise silently shrink the pool.
+func Dial(ctx context.Context, urls []string, cfg Config) (*Pool, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no RPC endpoints")
+	}
+	if cfg.Quorum > len(urls) {
+		return nil, fmt.Errorf("quorum of %d needs at least that many endpoints, have %d", cfg.Quorum, len(urls))
+	}
+	if cfg.CheckInterval == 0 {
+		cfg.CheckInterval = DefaultCheckInterval
+	}
+	p := &Pool{cfg: cfg}
+	for _, raw := range urls {
+		client, err := ethclient.DialContext(ctx, raw)
+		if err != nil {
+			p.Close()
+			return nil, fmt.Errorf("dial %s: %w", redact(raw), err)
+		}
+		p.endpoints = append(p.endpoints, &endpoint{url: redact(raw), client: client})
+	}
+	p.check(ctx)
+	return p, nil
+}
+
+// Close closes every endpoint's connection.
+func (p *Pool) Close() {
+	for _, e := range p.endpoints {
+		e.client.Close()
+	}
+}
+
+// Run probes every endpoint each CheckInterval until ctx is cancelled, so
+// that lagging or failed endpoints are passed over and recovered ones are
+// used again.
+func (p *Pool) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.cfg.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			p.check(ctx)
+		}
+	}
+}
+
+// check records each endpoint's head and latency and marks the ones more
+// than MaxLag blocks behind the best head.
+func (p *Pool) check(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, e := range p.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			head, err := e.client.BlockNumber(ctx)
+			e.record(err, time.Since(start))
+			if err == nil {
+				e.mu.Lock()
+				e.head = head
+				e.mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	var best uint64
+	for _, e := range p.endpoints {
+		best = max(best, e.snapshot().head)
+	}
+	for _, e := range p.endpoints {
+		e.mu.Lock()
+		lagging := e.head+p.cfg.MaxLag < best
+		if lagging && !e.lagging {
+			log.Printf("RPC endpoint %s is %d blocks behind, passing it over", e.url, best-e.head)
+		}
+		e.lagging = lagging
+		e.mu.Unlock()
+	}
+}
+
+// record updates an endpoint's score after a request. Errors the node
+// answered with (like a reverted call) say nothing about its health.
+func (e *endpoint) record(err error, took time.Duration) {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) || errors.Is(err, ethereum.NotFound) || errors.Is(err, context.Canceled) {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err != nil {
+		if e.failures == 0 {
+			log.Printf("RPC endpoint %s failed: %v", e.url, err)
+		}
+		e.failures++
+		return
+	}
+	e.failures = 0
+	// Exponentially weighted, so one slow request doesn't reorder the pool.
+	e.latency = (e.latency*3 + took) / 4
+}
+
+type endpointState struct {
+	head     uint64
+	failures int
+	latency  time.Duration
+	lagging  bool
+}
+
+func (e *endpoint) snapshot() endpointState {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return endpointState{head: e.head, failures: e.failures, latency: e.latency, lagging: e.lagging}
+}
+
+// ranked returns the endpoints best first: in sync before lagging, then
+// fewest consecutive failures, then lowest latency.
+func (p *Pool) ranked() []*endpoint {
+	type scored struct {
+		e *endpoint
+		s endpointState
+	}
+	all := make([]scored, len(p.endpoints))
+	for i, e := range p.endpoints {
+		all[i] = scored{e, e.snapshot()}
+	}
+	slices.SortStableFunc(all, func(a, b scored) int {
+		switch {
+		case a.s.lagging != b.s.lagging:
+			if a.s.lagging {
+				return 1
+			}
+			return -1
+		case a.s.failures != b.s.failures:
+			return a.s.failures - b.s.failures
+		default:
+			return int(a.s.latency - b.s.latency)
+		}
+	})
+	ranked := make([]*endpoint, len(all))
+	for i, s := range all {
+		ranked[i] = s.e
+	}
+	return ranked
+}
+
+// do runs fn against the best endpoint, failing over to the next one until
+// fn succeeds, the node itself rejects the request, or every endpoint has
+// failed.
+func do[T any](ctx context.Context, p *Pool, fn func(*ethclient.Client) (T, error)) (T, error) {
+	var errs []error
+	for _, e := range p.ranked() {
+		start := time.Now()
+		v, err := fn(e.client)
+		e.record(err, time.Since(start))
+		if err == nil || !failover(ctx, err) {
+			return v, err
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", e.url, err))
+	}
+	var zero T
+	return zero, errors.Join(errs...)
+}
+
+// failover reports whether err is worth retrying on another endpoint.
+func failover(ctx context.Context, err error) bool {
+	var rpcErr rpc.Error
+	return ctx.Err() == nil && !errors.As(err, &rpcErr) && !errors.Is(err, ethereum.NotFound)
+}
+
+// agree runs fn against endpoints, best first, until Quorum of them have
+// returned equal results, and returns that result. It fails if no Quorum
+// endpoints agree.
+func agree[T any](ctx context.Context, p *Pool, what string, equal func(a, b T) bool, fn func(*ethclient.Client) (T, error)) (T, error) {
+	if p.cfg.Quorum <= 1 {
+		return do(ctx, p, fn)
+	}
+	type answer struct {
+		v     T
+		votes int
+	}
+	var (
+		answers []answer
+		errs    []error
+	)
+	for _, e := range p.ranked() {
+		start := time.Now()
+		v, err := fn(e.client)
+		e.record(err, time.Since(start))
+		if err != nil {
+			if !failover(ctx, err) {
+				var zero T
+				return zero, err
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", e.url, err))
+			continue
+		}
+		i := slices.IndexFunc(answers, func(a answer) bool { return equal(a.v, v) })
+		if i < 0 {
+			answers = append(answers, answer{v: v})
+			i = len(answers) - 1
+		}
+		answers[i].votes++
+		if answers[i].votes >= p.cfg.Quorum {
+			return v, nil
+		}
+	}
+	var zero T
+	if len(answers) > 1 {
+		errs = append(errs, fmt.Errorf("endpoints disagree on %s", what))
+	}
+	return zero, fmt.Errorf("no quorum of %d on %s: %w", p.cfg.Quorum, what, errors.Join(errs...))
+}
+
+// trustedHead returns the highest block that Quorum endpoints have reached.
+func (p *Pool) trustedHead(ctx context.Context) (uint64, error) {
+	if p.cfg.Quorum <= 1 {
+		return do(ctx, p, func(c *ethclient.Client) (uint64, error) { return c.BlockNumber(ctx) })
+	}
+	var (
+		heads []uint64
+		errs  []error
+	)
+	for _, e := range p.endpoints {
+		start := time.Now()
+		head, err := e.client.BlockNumber(ctx)
+		e.record(err, time.Since(start))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.url, err))
+			continue
+		}
+		heads = append(heads, head)
+	}
+	if len(heads) < p.cfg.Quorum {
+		return 0, fmt.Errorf("no quorum of %d on the head block: %w", p.cfg.Quorum, errors.Join(errs...))
+	}
+	slices.Sort(heads)
+	return heads[len(heads)-p.cfg.Quorum], nil
+}
+
+// The methods below give Pool the same surface as *ethclient.Client for
+// what the indexers use.
+
+func (p *Pool) ChainID(ctx context.Context) (*big.Int, error) {
+	return agree(ctx, p, "the chain ID", func(a, b *big.Int) bool { return a.Cmp(b) == 0 },
+		func(c *ethclient.Client) (*big.Int, error) { return c.ChainID(ctx) })
+}
+
+func (p *Pool) BlockNumber(ctx context.Context) (uint64, error) {
+	return p.trustedHead(ctx)
+}
+
+// HeaderByNumber pins a nil number to the trusted head, so in quorum mode
+// the header returned for "latest" is one enough endpoints have.
+func (p *Pool) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil && p.cfg.Quorum > 1 {
+		head, err := p.trustedHead(ctx)
+		if err != nil {
+			return nil, err
+		}
+		number = new(big.Int).SetUint64(head)
+	}
+	return agree(ctx, p, fmt.Sprintf("header %v", number), func(a, b *types.Header) bool { return a.Hash() == b.Hash() },
+		func(c *ethclient.Client) (*types.Header, error) { return c.HeaderByNumber(ctx, number) })
+}
+
+func (p *Pool) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return do(ctx, p, func(c *ethclient.Client) ([]types.Log, error) { return c.FilterLogs(ctx, q) })
+}
+
+func (p *Pool) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return agree(ctx, p, "contract code", bytes.Equal,
+		func(c *ethclient.Client) ([]byte, error) { return c.CodeAt(ctx, contract, blockNumber) })
+}
+
+// CallContract pins calls at the latest block to the trusted head, so every
+// endpoint asked answers for the same state.
+func (p *Pool) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if blockNumber == nil && p.cfg.Quorum > 1 {
+		head, err := p.trustedHead(ctx)
+		if err != nil {
+			return nil, err
+		}
+		blockNumber = new(big.Int).SetUint64(head)
+	}
+	return agree(ctx, p, "eth_call", bytes.Equal,
+		func(c *ethclient.Client) ([]byte, error) { return c.CallContract(ctx, call, blockNumber) })
+}
+
+// SubscribeFilterLogs subscribes on the best endpoint. If that endpoint
+// drops, the subscription fails and the indexer resubscribes, which then
+// lands on the next best one.
+func (p *Pool) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return do(ctx, p, func(c *ethclient.Client) (ethereum.Subscription, error) { return c.SubscribeFilterLogs(ctx, q, ch) })
+}
+
+func (p *Pool) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return do(ctx, p, func(c *ethclient.Client) (ethereum.Subscription, error) { return c.SubscribeNewHead(ctx, ch) })
+}
+
+// redact strips everything but the scheme and host from an endpoint URL for
+// logging, since hosted providers put the API key in the path or query.
+func redact(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "<invalid URL>"
+	}
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,199 @@
+package rpcpool
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// fakeNode answers the eth_ methods the tests need. While down it fails
+// every request with a 503.
+type fakeNode struct {
+	mu      sync.Mutex
+	head    uint64
+	chainID int64
+	down    bool
+	// chainIDCalls counts the eth_chainId requests answered.
+	chainIDCalls int
+}
+
+func (n *fakeNode) BlockNumber() hexutil.Uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return hexutil.Uint64(n.head)
+}
+
+func (n *fakeNode) ChainId() *hexutil.Big {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.chainIDCalls++
+	return (*hexutil.Big)(big.NewInt(n.chainID))
+}
+
+func (n *fakeNode) set(head uint64, down bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.head, n.down = head, down
+}
+
+func (n *fakeNode) calls() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.chainIDCalls
+}
+
+func serve(t *testing.T, node *fakeNode) *httptest.Server {
+	t.Helper()
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("eth", node); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		node.mu.Lock()
+		down := node.down
+		node.mu.Unlock()
+		if down {
+			http.Error(w, "node is down", http.StatusServiceUnavailable)
+			return
+		}
+		srv.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// chainIDFrom checks that a ChainID request is answered by want and not by
+// other.
+func chainIDFrom(t *testing.T, p *Pool, want, other *fakeNode) {
+	t.Helper()
+	wantCalls, otherCalls := want.calls(), other.calls()
+	if _, err := p.ChainID(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want.calls() != wantCalls+1 || other.calls() != otherCalls {
+		t.Errorf("ChainID went to the wrong endpoint")
+	}
+}
+
+func TestFailover(t *testing.T) {
+	down := serve(t, &fakeNode{head: 10, chainID: 1})
+	up := serve(t, &fakeNode{head: 10, chainID: 1})
+	down.Close()
+
+	p, err := Dial(context.Background(), []string{down.URL, up.URL}, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	if got := p.ranked()[0].url; got != redact(up.URL) {
+		t.Errorf("best endpoint is %s, want the one that is up", got)
+	}
+	chainID, err := p.ChainID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chainID.Int64() != 1 {
+		t.Errorf("chain ID %d, want 1", chainID)
+	}
+}
+
+func TestQuorum(t *testing.T) {
+	urls := []string{
+		serve(t, &fakeNode{head: 12, chainID: 1}).URL,
+		serve(t, &fakeNode{head: 10, chainID: 1}).URL,
+		serve(t, &fakeNode{head: 11, chainID: 5}).URL,
+	}
+	p, err := Dial(context.Background(), urls, Config{Quorum: 2, MaxLag: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	head, err := p.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head != 11 {
+		t.Errorf("trusted head %d, want 11, the highest block two endpoints have", head)
+	}
+	chainID, err := p.ChainID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chainID.Int64() != 1 {
+		t.Errorf("chain ID %d, want the majority's 1", chainID)
+	}
+
+	p.cfg.Quorum = 3
+	if _, err := p.ChainID(context.Background()); err == nil {
+		t.Error("ChainID succeeded although the endpoints disagree")
+	}
+}
+
+// An endpoint more than MaxLag blocks behind is passed over until it
+// catches up, and then used again.
+func TestMaxLag(t *testing.T) {
+	a := &fakeNode{head: 100, chainID: 1}
+	b := &fakeNode{head: 100, chainID: 1}
+	p, err := Dial(context.Background(), []string{serve(t, a).URL, serve(t, b).URL}, Config{MaxLag: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	endpointA := p.endpoints[0]
+
+	a.set(94, false)
+	p.check(context.Background())
+	if !endpointA.snapshot().lagging || p.ranked()[0] == endpointA {
+		t.Fatalf("an endpoint 6 blocks behind is still ranked first: %+v", endpointA.snapshot())
+	}
+	chainIDFrom(t, p, b, a)
+
+	// 5 blocks behind is within MaxLag.
+	a.set(95, false)
+	p.check(context.Background())
+	if endpointA.snapshot().lagging {
+		t.Error("an endpoint MaxLag blocks behind is passed over")
+	}
+
+	// Once a is ahead, b is the one passed over.
+	a.set(110, false)
+	p.check(context.Background())
+	if endpointA.snapshot().lagging || p.ranked()[0] != endpointA {
+		t.Fatalf("the caught-up endpoint isn't ranked first: %+v", endpointA.snapshot())
+	}
+	chainIDFrom(t, p, a, b)
+}
+
+// A failed endpoint is ranked last, and used again once a check finds it
+// answering.
+func TestRecovery(t *testing.T) {
+	a := &fakeNode{head: 100, chainID: 1, down: true}
+	b := &fakeNode{head: 100, chainID: 1}
+	p, err := Dial(context.Background(), []string{serve(t, a).URL, serve(t, b).URL}, Config{MaxLag: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	endpointA := p.endpoints[0]
+
+	if endpointA.snapshot().failures == 0 || p.ranked()[0] == endpointA {
+		t.Fatalf("a failed endpoint is ranked first: %+v", endpointA.snapshot())
+	}
+	chainIDFrom(t, p, b, a)
+
+	a.set(100, false)
+	b.set(100, true)
+	p.check(context.Background())
+	if endpointA.snapshot().failures != 0 || p.ranked()[0] != endpointA {
+		t.Fatalf("the recovered endpoint isn't ranked first: %+v", endpointA.snapshot())
+	}
+	chainIDFrom(t, p, a, b)
+}
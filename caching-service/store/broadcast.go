@@ -0,0 +1,45 @@
+package store
+
+import (
+	"context"
+	"sync"
+)
+
+// broadcaster delivers committed deal updates to in-process listeners, for
+// the stores that have no NOTIFY of their own.
+type broadcaster struct {
+	mu        sync.Mutex
+	next      int
+	listeners map[int]func(DealUpdate)
+}
+
+func (b *broadcaster) listen(ctx context.Context, fn func(DealUpdate)) error {
+	b.mu.Lock()
+	if b.listeners == nil {
+		b.listeners = make(map[int]func(DealUpdate))
+	}
+	id := b.next
+	b.next++
+	b.listeners[id] = fn
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.listeners, id)
+	b.mu.Unlock()
+	return ctx.Err()
+}
+
+func (b *broadcaster) publish(updates []DealUpdate) {
+	if len(updates) == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, u := range updates {
+		for _, fn := range b.listeners {
+			fn(u)
+		}
+	}
+}
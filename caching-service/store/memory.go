@@ -0,0 +1,763 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Memory is a Store that keeps everything in process memory, for tests and
+// quick local runs. A Tx holds the store exclusively until it ends and
+// undoes its writes on Rollback.
+type Memory struct {
+	mu      sync.RWMutex
+	updates broadcaster
+
+	nextID      int64
+	checkpoints map[contractKey]Checkpoint
+	logs        []memLog
+	deals       map[addressKey]Deal
+	dealEvents  []memDealEvent
+	arbiters    map[addressKey]Arbiter
+	registry    []memRegistryEvent
+	profiles    map[addressKey]Profile
+	profileLog  []memProfileEvent
+	transfers   []memTransfer
+	approvals   []memApproval
+	balances    map[balanceKey]*big.Int
+}
+
+type contractKey struct {
+	chainID  int64
+	contract common.Address
+}
+
+type addressKey struct {
+	chainID int64
+	address string
+}
+
+type balanceKey struct {
+	chainID int64
+	token   common.Address
+	holder  common.Address
+}
+
+// position is where in the chain a stored event came from.
+type position struct {
+	block    uint64
+	logIndex uint
+	tx       common.Hash
+}
+
+func positionOf(vLog types.Log) position {
+	return position{block: vLog.BlockNumber, logIndex: vLog.Index, tx: vLog.TxHash}
+}
+
+func (p position) after(q position) bool {
+	return p.block > q.block || (p.block == q.block && p.logIndex > q.logIndex)
+}
+
+// applied reports whether events already holds one from vLog on chainID.
+// Events are keyed by their transaction and log index, like the unique
+// indexes of the SQL backends.
+func applied[T any](events []T, chainID int64, vLog types.Log, of func(T) (int64, position)) bool {
+	for _, e := range events {
+		if c, pos := of(e); c == chainID && pos.tx == vLog.TxHash && pos.logIndex == vLog.Index {
+			return true
+		}
+	}
+	return false
+}
+
+type memLog struct {
+	key contractKey
+	cp  Checkpoint
+}
+
+type memDealEvent struct {
+	chainID int64
+	escrow  string
+	pos     position
+	event   DealEvent
+}
+
+type memRegistryEvent struct {
+	pos   position
+	event RegistryEvent
+}
+
+type memProfileEvent struct {
+	pos   position
+	event ProfileEvent
+}
+
+type memTransfer struct {
+	pos      position
+	transfer Transfer
+}
+
+type memApproval struct {
+	pos      position
+	approval Approval
+}
+
+// NewMemory returns an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{
+		checkpoints: make(map[contractKey]Checkpoint),
+		deals:       make(map[addressKey]Deal),
+		arbiters:    make(map[addressKey]Arbiter),
+		profiles:    make(map[addressKey]Profile),
+		balances:    make(map[balanceKey]*big.Int),
+	}
+}
+
+func (m *Memory) Close() error { return nil }
+
+func (m *Memory) Begin(ctx context.Context) (Tx, error) {
+	m.mu.Lock()
+	return &memoryTx{m: m}, nil
+}
+
+func (m *Memory) ListenDealUpdates(ctx context.Context, fn func(DealUpdate)) error {
+	return m.updates.listen(ctx, fn)
+}
+
+func (m *Memory) Checkpoint(ctx context.Context, chainID int64, contract common.Address) (*Checkpoint, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	cp, ok := m.checkpoints[contractKey{chainID, contract}]
+	if !ok {
+		return nil, nil
+	}
+	return &cp, nil
+}
+
+func (m *Memory) IngestedBlock(ctx context.Context, chainID int64, contract common.Address, hash common.Hash) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	key := contractKey{chainID, contract}
+	for _, l := range m.logs {
+		if l.key == key && l.cp.BlockHash == hash {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *Memory) IngestedBlocks(ctx context.Context, chainID int64, contract common.Address, before uint64) ([]Block, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	key := contractKey{chainID, contract}
+	seen := make(map[Block]bool)
+	var blocks []Block
+	for _, l := range m.logs {
+		b := Block{Number: l.cp.Block, Hash: l.cp.BlockHash}
+		if l.key == key && b.Number < before && !seen[b] {
+			seen[b] = true
+			blocks = append(blocks, b)
+		}
+	}
+	slices.SortFunc(blocks, func(a, b Block) int { return cmp.Compare(b.Number, a.Number) })
+	return blocks, nil
+}
+
+func (m *Memory) Escrows(ctx context.Context, chainID int64) ([]common.Address, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var escrows []common.Address
+	for key := range m.deals {
+		if key.chainID == chainID {
+			escrows = append(escrows, common.HexToAddress(key.address))
+		}
+	}
+	return escrows, nil
+}
+
+func (m *Memory) EscrowTokens(ctx context.Context, chainID int64) ([]common.Address, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	seen := make(map[common.Address]bool)
+	var tokens []common.Address
+	for key, d := range m.deals {
+		if key.chainID != chainID || d.Token == nil {
+			continue
+		}
+		if token := common.HexToAddress(*d.Token); !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (m *Memory) ConfirmDeals(ctx context.Context, chainID int64, upTo uint64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int64
+	for key, d := range m.deals {
+		if key.chainID == chainID && d.ConfirmationStatus == StatusPending && d.BlockNumber != nil && uint64(*d.BlockNumber) <= upTo {
+			d.ConfirmationStatus = StatusConfirmed
+			m.deals[key] = d
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *Memory) Deals(ctx context.Context, q DealQuery) ([]Deal, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	deals := []Deal{}
+	for _, d := range m.deals {
+		if q.matches(d) {
+			deals = append(deals, m.withProfiles(d))
+		}
+	}
+	slices.SortFunc(deals, func(a, b Deal) int { return cmp.Compare(b.ID, a.ID) })
+	if q.Limit > 0 && len(deals) > q.Limit {
+		deals = deals[:q.Limit]
+	}
+	return deals, nil
+}
+
+func (q DealQuery) matches(d Deal) bool {
+	switch {
+	case q.ChainID != 0 && d.ChainID != q.ChainID,
+		q.Escrow != (common.Address{}) && d.Address != q.Escrow.Hex(),
+		q.Status != nil && d.Status != *q.Status,
+		q.Token != (common.Address{}) && (d.Token == nil || *d.Token != q.Token.Hex()),
+		q.Before > 0 && d.ID >= q.Before:
+		return false
+	}
+	if q.User == (common.Address{}) {
+		return true
+	}
+	user := q.User.Hex()
+	switch q.Role {
+	case "client":
+		return d.Client == user
+	case "freelancer":
+		return d.Freelancer == user
+	case "arbiter":
+		return d.Arbiter == user
+	default:
+		return d.Client == user || d.Freelancer == user || d.Arbiter == user
+	}
+}
+
+// withProfiles fills in the client's and freelancer's active profiles.
+func (m *Memory) withProfiles(d Deal) Deal {
+	if p, ok := m.profiles[addressKey{d.ChainID, d.Client}]; ok && p.IsActive {
+		d.ClientUsername, d.ClientAvatarHash = &p.Username, &p.ProfileImageHash
+	}
+	if p, ok := m.profiles[addressKey{d.ChainID, d.Freelancer}]; ok && p.IsActive {
+		d.FreelancerUsername, d.FreelancerAvatarHash = &p.Username, &p.ProfileImageHash
+	}
+	return d
+}
+
+func (m *Memory) DealEvents(ctx context.Context, chainID int64, escrows ...common.Address) ([]DealEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var matching []memDealEvent
+	for _, e := range m.dealEvents {
+		if e.chainID == chainID && matchesAny(e.escrow, escrows) {
+			matching = append(matching, e)
+		}
+	}
+	slices.SortFunc(matching, func(a, b memDealEvent) int { return comparePositions(a.pos, b.pos) })
+	events := []DealEvent{}
+	for _, e := range matching {
+		event := e.event
+		event.Escrow = e.escrow
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// matchesAny reports whether addr, as stored, is one of addrs.
+func matchesAny(addr string, addrs []common.Address) bool {
+	return slices.ContainsFunc(addrs, func(a common.Address) bool { return a.Hex() == addr })
+}
+
+func comparePositions(a, b position) int {
+	if c := cmp.Compare(a.block, b.block); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.logIndex, b.logIndex)
+}
+
+func (m *Memory) Arbiters(ctx context.Context, q ArbiterQuery) ([]Arbiter, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	arbiters := []Arbiter{}
+	for _, a := range m.arbiters {
+		if (q.ChainID == 0 || a.ChainID == q.ChainID) &&
+			(q.Address == (common.Address{}) || a.Address == q.Address.Hex()) &&
+			(len(q.Addresses) == 0 || matchesAny(a.Address, q.Addresses)) &&
+			(!q.ActiveOnly || a.IsActive) {
+			arbiters = append(arbiters, a)
+		}
+	}
+	slices.SortFunc(arbiters, func(a, b Arbiter) int {
+		return cmp.Or(cmp.Compare(a.ChainID, b.ChainID), cmp.Compare(a.AddedBlock, b.AddedBlock), cmp.Compare(a.Address, b.Address))
+	})
+	if q.Limit > 0 && len(arbiters) > q.Limit {
+		arbiters = arbiters[:q.Limit]
+	}
+	return arbiters, nil
+}
+
+func (m *Memory) Profiles(ctx context.Context, q ProfileQuery) ([]Profile, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	profiles := []Profile{}
+	for _, p := range m.profiles {
+		if (q.ChainID == 0 || p.ChainID == q.ChainID) &&
+			(q.Address == (common.Address{}) || p.Address == q.Address.Hex()) &&
+			(len(q.Addresses) == 0 || matchesAny(p.Address, q.Addresses)) &&
+			(!q.ActiveOnly || p.IsActive) {
+			profiles = append(profiles, p)
+		}
+	}
+	slices.SortFunc(profiles, func(a, b Profile) int {
+		return cmp.Or(cmp.Compare(a.ChainID, b.ChainID), cmp.Compare(a.Address, b.Address))
+	})
+	if q.Limit > 0 && len(profiles) > q.Limit {
+		profiles = profiles[:q.Limit]
+	}
+	return profiles, nil
+}
+
+// memoryTx writes straight to its Memory, which it holds locked, and keeps
+// a log of how to undo each write.
+type memoryTx struct {
+	m       *Memory
+	undo    []func()
+	pending []DealUpdate
+	done    bool
+}
+
+var errTxDone = errors.New("transaction has already been committed or rolled back")
+
+func (t *memoryTx) Commit() error {
+	if t.done {
+		return errTxDone
+	}
+	t.done = true
+	t.m.mu.Unlock()
+	t.m.updates.publish(t.pending)
+	return nil
+}
+
+func (t *memoryTx) Rollback() error {
+	if t.done {
+		return errTxDone
+	}
+	t.done = true
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		t.undo[i]()
+	}
+	t.m.mu.Unlock()
+	return nil
+}
+
+// set writes m[k] = v, undoably.
+func set[K comparable, V any](t *memoryTx, m map[K]V, k K, v V) {
+	old, had := m[k]
+	t.undo = append(t.undo, func() {
+		if had {
+			m[k] = old
+		} else {
+			delete(m, k)
+		}
+	})
+	m[k] = v
+}
+
+// remove deletes m[k], undoably.
+func remove[K comparable, V any](t *memoryTx, m map[K]V, k K) {
+	old, had := m[k]
+	if !had {
+		return
+	}
+	t.undo = append(t.undo, func() { m[k] = old })
+	delete(m, k)
+}
+
+// push appends v to *s, undoably.
+func push[T any](t *memoryTx, s *[]T, v T) {
+	old := *s
+	t.undo = append(t.undo, func() { *s = old })
+	*s = append(*s, v)
+}
+
+// drop removes the elements of *s for which del is true, undoably. The
+// filtered slice is a copy, so the original stays intact for the undo.
+func drop[T any](t *memoryTx, s *[]T, del func(T) bool) int {
+	old := *s
+	kept := make([]T, 0, len(old))
+	for _, v := range old {
+		if !del(v) {
+			kept = append(kept, v)
+		}
+	}
+	t.undo = append(t.undo, func() { *s = old })
+	*s = kept
+	return len(old) - len(kept)
+}
+
+func (t *memoryTx) RecordLog(ctx context.Context, chainID int64, contract common.Address, vLog types.Log) error {
+	l := memLog{key: contractKey{chainID, contract}, cp: CheckpointOf(vLog)}
+	drop(t, &t.m.logs, func(o memLog) bool {
+		return o.key == l.key && o.cp.Block == l.cp.Block && o.cp.LogIndex == l.cp.LogIndex
+	})
+	push(t, &t.m.logs, l)
+	return nil
+}
+
+func (t *memoryTx) DeleteLogs(ctx context.Context, chainID int64, contract common.Address, from uint64) (int64, error) {
+	key := contractKey{chainID, contract}
+	n := drop(t, &t.m.logs, func(l memLog) bool { return l.key == key && l.cp.Block >= from })
+	return int64(n), nil
+}
+
+func (t *memoryTx) LastLog(ctx context.Context, chainID int64, contract common.Address) (*Checkpoint, error) {
+	key := contractKey{chainID, contract}
+	var last *Checkpoint
+	for _, l := range t.m.logs {
+		if l.key != key {
+			continue
+		}
+		if last == nil || (position{block: l.cp.Block, logIndex: l.cp.LogIndex}).after(position{block: last.Block, logIndex: last.LogIndex}) {
+			cp := l.cp
+			last = &cp
+		}
+	}
+	return last, nil
+}
+
+func (t *memoryTx) SaveCheckpoint(ctx context.Context, chainID int64, contract common.Address, cp Checkpoint) error {
+	set(t, t.m.checkpoints, contractKey{chainID, contract}, cp)
+	return nil
+}
+
+func (t *memoryTx) AdvanceCheckpoint(ctx context.Context, chainID int64, contract common.Address, cp Checkpoint) error {
+	key := contractKey{chainID, contract}
+	if old, ok := t.m.checkpoints[key]; ok && !(position{block: cp.Block, logIndex: cp.LogIndex}).after(position{block: old.Block, logIndex: old.LogIndex}) {
+		return nil
+	}
+	set(t, t.m.checkpoints, key, cp)
+	return nil
+}
+
+func (t *memoryTx) DeleteCheckpoint(ctx context.Context, chainID int64, contract common.Address) error {
+	remove(t, t.m.checkpoints, contractKey{chainID, contract})
+	return nil
+}
+
+func (t *memoryTx) InsertDeal(ctx context.Context, d NewDeal, vLog types.Log) (bool, error) {
+	key := addressKey{d.ChainID, d.Escrow.Hex()}
+	if _, ok := t.m.deals[key]; ok {
+		return false, nil
+	}
+	t.m.nextID++
+	block := int64(vLog.BlockNumber)
+	txHash := vLog.TxHash.Hex()
+	deal := Deal{
+		ID:                 t.m.nextID,
+		ChainID:            d.ChainID,
+		Address:            key.address,
+		Client:             d.Client.Hex(),
+		Freelancer:         d.Freelancer.Hex(),
+		Arbiter:            d.Arbiter.Hex(),
+		TotalAmount:        d.TotalAmount.String(),
+		ProjectDescription: d.ProjectDescription,
+		Status:             int(d.Status),
+		ConfirmationStatus: d.ConfirmationStatus,
+		BlockNumber:        &block,
+		TxHash:             &txHash,
+		CreatedAt:          time.Now(),
+	}
+	if d.Token != nil {
+		token := d.Token.Hex()
+		deal.Token = &token
+	}
+	set(t, t.m.deals, key, deal)
+	return true, nil
+}
+
+func (t *memoryTx) ApplyDealEvent(ctx context.Context, e NewDealEvent, vLog types.Log) (bool, error) {
+	key := addressKey{e.ChainID, e.Escrow.Hex()}
+	deal, ok := t.m.deals[key]
+	if !ok {
+		return false, fmt.Errorf("insert %s for %s: no such deal", e.Name, key.address)
+	}
+	if applied(t.m.dealEvents, e.ChainID, vLog, func(d memDealEvent) (int64, position) { return d.chainID, d.pos }) {
+		return false, nil
+	}
+	event := DealEvent{
+		Name:           e.Name,
+		WorkSubmission: e.WorkSubmission,
+		Status:         int(e.Status),
+		BlockNumber:    int64(vLog.BlockNumber),
+		TxHash:         vLog.TxHash.Hex(),
+		LogIndex:       int(vLog.Index),
+	}
+	if e.Actor != nil {
+		actor := e.Actor.Hex()
+		event.Actor = &actor
+	}
+	if e.Amount != nil {
+		amount := e.Amount.String()
+		event.Amount = &amount
+	}
+	pos := positionOf(vLog)
+	// The status follows the latest event, so an older event applied late
+	// doesn't move the deal backwards.
+	superseded := slices.ContainsFunc(t.m.dealEvents, func(d memDealEvent) bool {
+		return d.chainID == e.ChainID && d.escrow == key.address && d.pos.after(pos)
+	})
+	push(t, &t.m.dealEvents, memDealEvent{chainID: e.ChainID, escrow: key.address, pos: pos, event: event})
+	if !superseded {
+		deal.Status = int(e.Status)
+		set(t, t.m.deals, key, deal)
+	}
+	return true, nil
+}
+
+func (t *memoryTx) RollbackDeals(ctx context.Context, chainID int64, from uint64) error {
+	// A deal's status is the one implied by its latest event, so deals that
+	// lose events fall back to the newest one that remains.
+	drop(t, &t.m.dealEvents, func(e memDealEvent) bool { return e.chainID == chainID && e.pos.block >= from })
+	for key, d := range t.m.deals {
+		if key.chainID != chainID {
+			continue
+		}
+		if d.BlockNumber != nil && uint64(*d.BlockNumber) >= from {
+			remove(t, t.m.deals, key)
+			continue
+		}
+		status := int(DealCreated)
+		var latest *position
+		for _, e := range t.m.dealEvents {
+			if e.chainID == chainID && e.escrow == key.address && (latest == nil || e.pos.after(*latest)) {
+				latest, status = &e.pos, e.event.Status
+			}
+		}
+		if d.Status != status {
+			d.Status = status
+			set(t, t.m.deals, key, d)
+		}
+	}
+	return nil
+}
+
+func (t *memoryTx) NotifyDealUpdate(ctx context.Context, chainID int64, escrow common.Address, event string, vLog types.Log) error {
+	d, ok := t.m.deals[addressKey{chainID, escrow.Hex()}]
+	if !ok {
+		return nil
+	}
+	t.pending = append(t.pending, DealUpdate{
+		Event:       event,
+		ChainID:     chainID,
+		Escrow:      d.Address,
+		Client:      d.Client,
+		Freelancer:  d.Freelancer,
+		Arbiter:     d.Arbiter,
+		Status:      d.Status,
+		BlockNumber: int64(vLog.BlockNumber),
+		TxHash:      vLog.TxHash.Hex(),
+	})
+	return nil
+}
+
+func (t *memoryTx) ApplyRegistryEvent(ctx context.Context, e RegistryEvent, vLog types.Log) (bool, error) {
+	if applied(t.m.registry, e.ChainID, vLog, func(r memRegistryEvent) (int64, position) { return r.event.ChainID, r.pos }) {
+		return false, nil
+	}
+	push(t, &t.m.registry, memRegistryEvent{pos: positionOf(vLog), event: e})
+	key := addressKey{e.ChainID, e.Address.Hex()}
+	switch e.Name {
+	case "ArbiterAdded":
+		set(t, t.m.arbiters, key, arbiterAdded(e, vLog.BlockNumber))
+	case "ArbiterRemoved":
+		if a, ok := t.m.arbiters[key]; ok {
+			removed := int64(vLog.BlockNumber)
+			a.IsActive, a.RemovedBlock = false, &removed
+			set(t, t.m.arbiters, key, a)
+		}
+	}
+	return true, nil
+}
+
+func arbiterAdded(e RegistryEvent, block uint64) Arbiter {
+	a := Arbiter{
+		ChainID:     e.ChainID,
+		Address:     e.Address.Hex(),
+		ProfileHash: e.ProfileHash,
+		IsActive:    true,
+		AddedBlock:  int64(block),
+	}
+	if e.ArbiterName != nil {
+		a.Name = *e.ArbiterName
+	}
+	return a
+}
+
+func (t *memoryTx) RollbackArbiters(ctx context.Context, chainID int64, from uint64) error {
+	affected := make(map[string]bool)
+	for _, r := range t.m.registry {
+		if r.event.ChainID == chainID && r.pos.block >= from && r.event.Name != "OwnershipTransferred" {
+			affected[r.event.Address.Hex()] = true
+		}
+	}
+	drop(t, &t.m.registry, func(r memRegistryEvent) bool { return r.event.ChainID == chainID && r.pos.block >= from })
+
+	// An arbiter is described by its latest ArbiterAdded, and is inactive if
+	// an ArbiterRemoved follows it.
+	for addr := range affected {
+		var added, removed *memRegistryEvent
+		for i, r := range t.m.registry {
+			if r.event.ChainID != chainID || r.event.Address.Hex() != addr {
+				continue
+			}
+			if r.event.Name == "ArbiterAdded" && (added == nil || r.pos.after(added.pos)) {
+				added = &t.m.registry[i]
+			}
+		}
+		key := addressKey{chainID, addr}
+		if added == nil {
+			remove(t, t.m.arbiters, key)
+			continue
+		}
+		for i, r := range t.m.registry {
+			if r.event.ChainID == chainID && r.event.Address.Hex() == addr && r.event.Name == "ArbiterRemoved" &&
+				r.pos.after(added.pos) && (removed == nil || r.pos.after(removed.pos)) {
+				removed = &t.m.registry[i]
+			}
+		}
+		a := arbiterAdded(added.event, added.pos.block)
+		if removed != nil {
+			block := int64(removed.pos.block)
+			a.IsActive, a.RemovedBlock = false, &block
+		}
+		set(t, t.m.arbiters, key, a)
+	}
+	return nil
+}
+
+func (t *memoryTx) ApplyProfileEvent(ctx context.Context, e ProfileEvent, vLog types.Log) (bool, error) {
+	if applied(t.m.profileLog, e.ChainID, vLog, func(p memProfileEvent) (int64, position) { return p.event.ChainID, p.pos }) {
+		return false, nil
+	}
+	key := addressKey{e.ChainID, e.Address.Hex()}
+	p := t.m.profiles[key]
+	switch e.Name {
+	case "ProfileUpdated":
+		p = Profile{ChainID: e.ChainID, Address: key.address, Username: e.Username, Bio: e.Bio, ProfileImageHash: e.ProfileImageHash, IsActive: true}
+	case "ProfileDeleted":
+		// deleteProfile can be called by an address that never set one, so
+		// the profile is created if needed.
+		p.ChainID, p.Address, p.IsActive = e.ChainID, key.address, false
+	default:
+		return false, fmt.Errorf("unknown profile event %s", e.Name)
+	}
+	push(t, &t.m.profileLog, memProfileEvent{pos: positionOf(vLog), event: e})
+	set(t, t.m.profiles, key, p)
+	return true, nil
+}
+
+func (t *memoryTx) RollbackProfiles(ctx context.Context, chainID int64, from uint64) error {
+	affected := make(map[string]bool)
+	for _, p := range t.m.profileLog {
+		if p.event.ChainID == chainID && p.pos.block >= from {
+			affected[p.event.Address.Hex()] = true
+		}
+	}
+	drop(t, &t.m.profileLog, func(p memProfileEvent) bool { return p.event.ChainID == chainID && p.pos.block >= from })
+
+	// A profile holds the fields of its latest ProfileUpdated and is active
+	// unless a ProfileDeleted came after it.
+	for addr := range affected {
+		var latest, updated *memProfileEvent
+		for i, p := range t.m.profileLog {
+			if p.event.ChainID != chainID || p.event.Address.Hex() != addr {
+				continue
+			}
+			if latest == nil || p.pos.after(latest.pos) {
+				latest = &t.m.profileLog[i]
+			}
+			if p.event.Name == "ProfileUpdated" && (updated == nil || p.pos.after(updated.pos)) {
+				updated = &t.m.profileLog[i]
+			}
+		}
+		key := addressKey{chainID, addr}
+		if latest == nil {
+			remove(t, t.m.profiles, key)
+			continue
+		}
+		p := Profile{ChainID: chainID, Address: addr, IsActive: latest.event.Name == "ProfileUpdated"}
+		if updated != nil {
+			p.Username, p.Bio, p.ProfileImageHash = updated.event.Username, updated.event.Bio, updated.event.ProfileImageHash
+		}
+		set(t, t.m.profiles, key, p)
+	}
+	return nil
+}
+
+func (t *memoryTx) ApplyTransfer(ctx context.Context, tr Transfer, vLog types.Log) (bool, error) {
+	if applied(t.m.transfers, tr.ChainID, vLog, func(o memTransfer) (int64, position) { return o.transfer.ChainID, o.pos }) {
+		return false, nil
+	}
+	push(t, &t.m.transfers, memTransfer{pos: positionOf(vLog), transfer: tr})
+	t.adjustBalance(tr.ChainID, tr.Token, tr.From, new(big.Int).Neg(tr.Amount))
+	t.adjustBalance(tr.ChainID, tr.Token, tr.To, tr.Amount)
+	return true, nil
+}
+
+// adjustBalance adds delta to holder's balance of token. The zero address
+// (mints and burns) has no balance.
+func (t *memoryTx) adjustBalance(chainID int64, token, holder common.Address, delta *big.Int) {
+	if holder == (common.Address{}) {
+		return
+	}
+	key := balanceKey{chainID, token, holder}
+	balance := new(big.Int).Add(cmp.Or(t.m.balances[key], new(big.Int)), delta)
+	set(t, t.m.balances, key, balance)
+}
+
+func (t *memoryTx) InsertApproval(ctx context.Context, a Approval, vLog types.Log) (bool, error) {
+	if applied(t.m.approvals, a.ChainID, vLog, func(o memApproval) (int64, position) { return o.approval.ChainID, o.pos }) {
+		return false, nil
+	}
+	push(t, &t.m.approvals, memApproval{pos: positionOf(vLog), approval: a})
+	return true, nil
+}
+
+func (t *memoryTx) RollbackToken(ctx context.Context, chainID int64, token common.Address, from uint64) error {
+	reverted := func(tr Transfer, pos position) bool {
+		return tr.ChainID == chainID && tr.Token == token && pos.block >= from
+	}
+	for _, tr := range t.m.transfers {
+		if reverted(tr.transfer, tr.pos) {
+			t.adjustBalance(chainID, token, tr.transfer.From, tr.transfer.Amount)
+			t.adjustBalance(chainID, token, tr.transfer.To, new(big.Int).Neg(tr.transfer.Amount))
+		}
+	}
+	drop(t, &t.m.transfers, func(tr memTransfer) bool { return reverted(tr.transfer, tr.pos) })
+	drop(t, &t.m.approvals, func(a memApproval) bool {
+		return a.approval.ChainID == chainID && a.approval.Token == token && a.pos.block >= from
+	})
+	return nil
+}
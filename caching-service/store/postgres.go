@@ -0,0 +1,200 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lib/pq"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/db/migrations"
+)
+
+// DealUpdatesChannel is the Postgres NOTIFY channel a JSON DealUpdate is
+// sent on. Notifications are sent inside the indexing transaction, so
+// listeners only hear about changes that committed.
+const DealUpdatesChannel = "deal_updates"
+
+// Postgres is the primary Store, shared by every instance of the service.
+type Postgres struct {
+	sqlStore
+	url string
+}
+
+// OpenPostgres connects to the database at url and applies any pending
+// migrations.
+func OpenPostgres(ctx context.Context, url string) (*Postgres, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, fmt.Errorf("open database connection: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	migrator, err := migrations.New(db)
+	if err == nil {
+		err = migrator.Up(ctx)
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate the database: %w", err)
+	}
+	return &Postgres{sqlStore: sqlStore{db: db, rebind: func(q string) string { return q }}, url: url}, nil
+}
+
+func (p *Postgres) Begin(ctx context.Context) (Tx, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pgTx{sqlTx{tx: tx, rebind: p.rebind}}, nil
+}
+
+// ListenDealUpdates listens on DealUpdatesChannel, so it also hears about
+// deals indexed by other instances.
+func (p *Postgres) ListenDealUpdates(ctx context.Context, fn func(DealUpdate)) error {
+	listener := pq.NewListener(p.url, 10*time.Second, time.Minute, nil)
+	defer listener.Close()
+	if err := listener.Listen(DealUpdatesChannel); err != nil {
+		return fmt.Errorf("listen for deal updates: %w", err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case n := <-listener.Notify:
+			// A nil notification means the connection was re-established;
+			// anything sent while it was down is lost.
+			if n == nil {
+				log.Printf("Deal update listener reconnected")
+				continue
+			}
+			var u DealUpdate
+			if err := json.Unmarshal([]byte(n.Extra), &u); err != nil {
+				log.Printf("Invalid deal update %q: %v", n.Extra, err)
+				continue
+			}
+			fn(u)
+		}
+	}
+}
+
+type pgTx struct {
+	sqlTx
+}
+
+func (t *pgTx) Commit() error   { return t.tx.Commit() }
+func (t *pgTx) Rollback() error { return t.tx.Rollback() }
+
+func (t *pgTx) NotifyDealUpdate(ctx context.Context, chainID int64, escrow common.Address, event string, vLog types.Log) error {
+	_, err := t.exec(ctx, `
+	SELECT pg_notify($1, json_build_object(
+		'event', $4::TEXT,
+		'chainId', chain_id,
+		'escrow', contract_address,
+		'client', client_address,
+		'freelancer', freelancer_address,
+		'arbiter', arbiter_address,
+		'status', status,
+		'blockNumber', $5::BIGINT,
+		'txHash', $6::TEXT
+	)::TEXT)
+	FROM deals WHERE chain_id = $2 AND contract_address = $3`,
+		DealUpdatesChannel, chainID, escrow.Hex(), event, vLog.BlockNumber, vLog.TxHash.Hex(),
+	)
+	if err != nil {
+		return fmt.Errorf("notify %s: %w", event, err)
+	}
+	return nil
+}
+
+func (t *pgTx) RollbackArbiters(ctx context.Context, chainID int64, from uint64) error {
+	// An arbiter is described by its latest ArbiterAdded, and is inactive if
+	// an ArbiterRemoved follows it.
+	return t.rollbackArbiters(ctx, chainID, from, `
+	INSERT INTO arbiters (chain_id, address, name, profile_hash, is_active, added_block, removed_block)
+	SELECT $1, a.address, a.name, a.profile_hash, r.block_number IS NULL, a.block_number, r.block_number
+	FROM (
+		SELECT DISTINCT ON (address) address, name, profile_hash, block_number, log_index
+		FROM registry_events
+		WHERE chain_id = $1 AND event_name = 'ArbiterAdded' AND block_number < $2 AND address IN (`+affectedArbiters+`)
+		ORDER BY address, block_number DESC, log_index DESC
+	) a
+	LEFT JOIN LATERAL (
+		SELECT block_number FROM registry_events r
+		WHERE r.chain_id = $1 AND r.event_name = 'ArbiterRemoved' AND r.address = a.address AND r.block_number < $2
+			AND (r.block_number, r.log_index) > (a.block_number, a.log_index)
+		ORDER BY r.block_number DESC, r.log_index DESC
+		LIMIT 1
+	) r ON TRUE`)
+}
+
+func (t *pgTx) RollbackProfiles(ctx context.Context, chainID int64, from uint64) error {
+	// A profile holds the fields of its latest ProfileUpdated and is active
+	// unless a ProfileDeleted came after it.
+	return t.rollbackProfiles(ctx, chainID, from, `
+	INSERT INTO profiles (chain_id, address, username, bio, profile_image_hash, is_active, block_number)
+	SELECT $1, l.address, COALESCE(u.username, ''), COALESCE(u.bio, ''), COALESCE(u.profile_image_hash, ''),
+		l.event_name = 'ProfileUpdated', l.block_number
+	FROM (
+		SELECT DISTINCT ON (address) address, event_name, block_number
+		FROM profile_events
+		WHERE chain_id = $1 AND block_number < $2 AND address IN (`+affectedProfiles+`)
+		ORDER BY address, block_number DESC, log_index DESC
+	) l
+	LEFT JOIN LATERAL (
+		SELECT username, bio, profile_image_hash FROM profile_events u
+		WHERE u.chain_id = $1 AND u.event_name = 'ProfileUpdated' AND u.address = l.address AND u.block_number < $2
+		ORDER BY u.block_number DESC, u.log_index DESC
+		LIMIT 1
+	) u ON TRUE`)
+}
+
+func (t *pgTx) ApplyTransfer(ctx context.Context, tr Transfer, vLog types.Log) (bool, error) {
+	if inserted, err := t.insertTransfer(ctx, tr, vLog); err != nil || !inserted {
+		return false, err
+	}
+	const adjust = `
+	INSERT INTO token_balances (chain_id, token_address, holder_address, balance)
+	VALUES ($1, $2, $3, $4::NUMERIC * $5)
+	ON CONFLICT (chain_id, token_address, holder_address)
+	DO UPDATE SET balance = token_balances.balance + EXCLUDED.balance, updated_at = NOW()`
+	amount := tr.Amount.String()
+	if tr.From != (common.Address{}) {
+		if _, err := t.exec(ctx, adjust, tr.ChainID, tr.Token.Hex(), tr.From.Hex(), amount, -1); err != nil {
+			return false, fmt.Errorf("debit %s: %w", tr.From.Hex(), err)
+		}
+	}
+	if tr.To != (common.Address{}) {
+		if _, err := t.exec(ctx, adjust, tr.ChainID, tr.Token.Hex(), tr.To.Hex(), amount, 1); err != nil {
+			return false, fmt.Errorf("credit %s: %w", tr.To.Hex(), err)
+		}
+	}
+	return true, nil
+}
+
+func (t *pgTx) RollbackToken(ctx context.Context, chainID int64, token common.Address, from uint64) error {
+	_, err := t.exec(ctx, `
+	UPDATE token_balances b SET balance = b.balance - d.delta, updated_at = NOW()
+	FROM (
+		SELECT holder, SUM(delta) AS delta FROM (
+			SELECT to_address AS holder, amount AS delta FROM token_transfers
+			WHERE chain_id = $1 AND token_address = $2 AND block_number >= $3
+			UNION ALL
+			SELECT from_address, -amount FROM token_transfers
+			WHERE chain_id = $1 AND token_address = $2 AND block_number >= $3
+		) moves
+		GROUP BY holder
+	) d
+	WHERE b.chain_id = $1 AND b.token_address = $2 AND b.holder_address = d.holder`,
+		chainID, token.Hex(), from)
+	if err != nil {
+		return fmt.Errorf("revert balances: %w", err)
+	}
+	return t.deleteTokenEvents(ctx, chainID, token, from)
+}
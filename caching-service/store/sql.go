@@ -0,0 +1,751 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// sqlStore holds what Postgres and SQLite have in common. Queries are
+// written for Postgres and passed through rebind, which adapts the
+// placeholders for SQLite.
+type sqlStore struct {
+	db     *sql.DB
+	rebind func(query string) string
+}
+
+// sqlTx holds the transaction methods whose SQL both backends accept.
+type sqlTx struct {
+	tx     *sql.Tx
+	rebind func(query string) string
+}
+
+func (s *sqlStore) exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return s.db.ExecContext(ctx, s.rebind(query), args...)
+}
+
+func (s *sqlStore) query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return s.db.QueryContext(ctx, s.rebind(query), args...)
+}
+
+func (s *sqlStore) queryRow(ctx context.Context, query string, args ...any) *sql.Row {
+	return s.db.QueryRowContext(ctx, s.rebind(query), args...)
+}
+
+func (t *sqlTx) exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.tx.ExecContext(ctx, t.rebind(query), args...)
+}
+
+func (t *sqlTx) query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return t.tx.QueryContext(ctx, t.rebind(query), args...)
+}
+
+func (t *sqlTx) queryRow(ctx context.Context, query string, args ...any) *sql.Row {
+	return t.tx.QueryRowContext(ctx, t.rebind(query), args...)
+}
+
+// insert runs an INSERT ... ON CONFLICT DO NOTHING and reports whether it
+// stored a row.
+func (t *sqlTx) insert(ctx context.Context, query string, args ...any) (bool, error) {
+	res, err := t.exec(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// wrap prefixes err with a description of what failed, and passes nil
+// through.
+func wrap(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf(format+": %w", append(args, err)...)
+}
+
+func (s *sqlStore) Close() error { return s.db.Close() }
+
+func (s *sqlStore) Checkpoint(ctx context.Context, chainID int64, contract common.Address) (*Checkpoint, error) {
+	var (
+		cp        Checkpoint
+		blockHash sql.NullString
+	)
+	err := s.queryRow(ctx, `
+	SELECT block_number, log_index, block_hash FROM indexer_checkpoints
+	WHERE chain_id = $1 AND contract_address = $2`,
+		chainID, contract.Hex(),
+	).Scan(&cp.Block, &cp.LogIndex, &blockHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint: %w", err)
+	}
+	cp.BlockHash = common.HexToHash(blockHash.String)
+	return &cp, nil
+}
+
+func (s *sqlStore) IngestedBlock(ctx context.Context, chainID int64, contract common.Address, hash common.Hash) (bool, error) {
+	var ingested bool
+	err := s.queryRow(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM indexed_logs
+		WHERE chain_id = $1 AND contract_address = $2 AND block_hash = $3
+	)`, chainID, contract.Hex(), hash.Hex(),
+	).Scan(&ingested)
+	if err != nil {
+		return false, fmt.Errorf("look up block: %w", err)
+	}
+	return ingested, nil
+}
+
+func (s *sqlStore) IngestedBlocks(ctx context.Context, chainID int64, contract common.Address, before uint64) ([]Block, error) {
+	rows, err := s.query(ctx, `
+	SELECT DISTINCT block_number, block_hash FROM indexed_logs
+	WHERE chain_id = $1 AND contract_address = $2 AND block_number < $3
+	ORDER BY block_number DESC`, chainID, contract.Hex(), before)
+	if err != nil {
+		return nil, fmt.Errorf("load ingested blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var blocks []Block
+	for rows.Next() {
+		var (
+			b    Block
+			hash string
+		)
+		if err := rows.Scan(&b.Number, &hash); err != nil {
+			return nil, err
+		}
+		b.Hash = common.HexToHash(hash)
+		blocks = append(blocks, b)
+	}
+	return blocks, rows.Err()
+}
+
+func (s *sqlStore) Escrows(ctx context.Context, chainID int64) ([]common.Address, error) {
+	return s.addresses(ctx, `SELECT contract_address FROM deals WHERE chain_id = $1`, chainID)
+}
+
+func (s *sqlStore) EscrowTokens(ctx context.Context, chainID int64) ([]common.Address, error) {
+	return s.addresses(ctx, `SELECT DISTINCT token_address FROM deals WHERE chain_id = $1 AND token_address IS NOT NULL`, chainID)
+}
+
+func (s *sqlStore) addresses(ctx context.Context, query string, args ...any) ([]common.Address, error) {
+	rows, err := s.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var addrs []common.Address
+	for rows.Next() {
+		var addr string
+		if err := rows.Scan(&addr); err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, common.HexToAddress(addr))
+	}
+	return addrs, rows.Err()
+}
+
+func (s *sqlStore) ConfirmDeals(ctx context.Context, chainID int64, upTo uint64) (int64, error) {
+	res, err := s.exec(ctx, `
+	UPDATE deals SET confirmation_status = $1
+	WHERE chain_id = $2 AND confirmation_status = $3 AND block_number <= $4`,
+		StatusConfirmed, chainID, StatusPending, upTo,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// filter builds a WHERE clause. Each condition uses ? for its arguments;
+// they are numbered as they are added.
+type filter struct {
+	conds []string
+	args  []any
+}
+
+func (f *filter) add(cond string, args ...any) {
+	for _, arg := range args {
+		f.args = append(f.args, arg)
+		cond = strings.Replace(cond, "?", "$"+strconv.Itoa(len(f.args)), 1)
+	}
+	f.conds = append(f.conds, cond)
+}
+
+// addIn adds a condition that column is one of addrs.
+func (f *filter) addIn(column string, addrs []common.Address) {
+	args := make([]any, len(addrs))
+	for i, addr := range addrs {
+		args[i] = addr.Hex()
+	}
+	f.add(column+" IN (?"+strings.Repeat(", ?", len(addrs)-1)+")", args...)
+}
+
+func (f *filter) where() string {
+	if len(f.conds) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(f.conds, " AND ")
+}
+
+func limit(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return " LIMIT " + strconv.Itoa(n)
+}
+
+const dealColumns = `id, chain_id, contract_address, client_address, freelancer_address, arbiter_address, total_amount,
+	token_address, project_description, status, confirmation_status, block_number, tx_hash,
+	client_username, client_avatar_hash, freelancer_username, freelancer_avatar_hash, created_at`
+
+func (s *sqlStore) Deals(ctx context.Context, q DealQuery) ([]Deal, error) {
+	var f filter
+	if q.ChainID != 0 {
+		f.add("chain_id = ?", q.ChainID)
+	}
+	if q.Escrow != (common.Address{}) {
+		f.add("contract_address = ?", q.Escrow.Hex())
+	}
+	// The lookup goes through user_escrows rather than scanning deals.
+	switch {
+	case q.User == (common.Address{}):
+	case q.Role == "":
+		f.add("id IN (SELECT deal_id FROM user_escrows WHERE user_address = ?)", q.User.Hex())
+	default:
+		f.add("id IN (SELECT deal_id FROM user_escrows WHERE user_address = ? AND role = ?)", q.User.Hex(), q.Role)
+	}
+	if q.Status != nil {
+		f.add("status = ?", *q.Status)
+	}
+	if q.Token != (common.Address{}) {
+		f.add("token_address = ?", q.Token.Hex())
+	}
+	if q.Before > 0 {
+		f.add("id < ?", q.Before)
+	}
+	rows, err := s.query(ctx, `SELECT `+dealColumns+` FROM deals_with_profiles `+f.where()+` ORDER BY id DESC`+limit(q.Limit), f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deals := []Deal{}
+	for rows.Next() {
+		var d Deal
+		err := rows.Scan(&d.ID, &d.ChainID, &d.Address, &d.Client, &d.Freelancer, &d.Arbiter, &d.TotalAmount,
+			&d.Token, &d.ProjectDescription, &d.Status, &d.ConfirmationStatus, &d.BlockNumber, &d.TxHash,
+			&d.ClientUsername, &d.ClientAvatarHash, &d.FreelancerUsername, &d.FreelancerAvatarHash, &d.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		deals = append(deals, d)
+	}
+	return deals, rows.Err()
+}
+
+func (s *sqlStore) DealEvents(ctx context.Context, chainID int64, escrows ...common.Address) ([]DealEvent, error) {
+	if len(escrows) == 0 {
+		return []DealEvent{}, nil
+	}
+	var f filter
+	f.add("chain_id = ?", chainID)
+	f.addIn("contract_address", escrows)
+	rows, err := s.query(ctx, `
+	SELECT contract_address, event_name, actor_address, amount, work_submission, status, block_number, tx_hash, log_index
+	FROM deal_events `+f.where()+`
+	ORDER BY block_number, log_index`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []DealEvent{}
+	for rows.Next() {
+		var e DealEvent
+		if err := rows.Scan(&e.Escrow, &e.Name, &e.Actor, &e.Amount, &e.WorkSubmission, &e.Status, &e.BlockNumber, &e.TxHash, &e.LogIndex); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+func (s *sqlStore) Arbiters(ctx context.Context, q ArbiterQuery) ([]Arbiter, error) {
+	var f filter
+	if q.ChainID != 0 {
+		f.add("chain_id = ?", q.ChainID)
+	}
+	if q.Address != (common.Address{}) {
+		f.add("address = ?", q.Address.Hex())
+	}
+	if len(q.Addresses) > 0 {
+		f.addIn("address", q.Addresses)
+	}
+	if q.ActiveOnly {
+		f.add("is_active")
+	}
+	rows, err := s.query(ctx, `
+	SELECT chain_id, address, name, profile_hash, is_active, added_block, removed_block
+	FROM arbiters `+f.where()+` ORDER BY chain_id, added_block`+limit(q.Limit), f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	arbiters := []Arbiter{}
+	for rows.Next() {
+		var a Arbiter
+		if err := rows.Scan(&a.ChainID, &a.Address, &a.Name, &a.ProfileHash, &a.IsActive, &a.AddedBlock, &a.RemovedBlock); err != nil {
+			return nil, err
+		}
+		arbiters = append(arbiters, a)
+	}
+	return arbiters, rows.Err()
+}
+
+func (s *sqlStore) Profiles(ctx context.Context, q ProfileQuery) ([]Profile, error) {
+	var f filter
+	if q.ChainID != 0 {
+		f.add("chain_id = ?", q.ChainID)
+	}
+	if q.Address != (common.Address{}) {
+		f.add("address = ?", q.Address.Hex())
+	}
+	if len(q.Addresses) > 0 {
+		f.addIn("address", q.Addresses)
+	}
+	if q.ActiveOnly {
+		f.add("is_active")
+	}
+	rows, err := s.query(ctx, `
+	SELECT chain_id, address, username, bio, profile_image_hash, is_active
+	FROM profiles `+f.where()+` ORDER BY chain_id, address`+limit(q.Limit), f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := []Profile{}
+	for rows.Next() {
+		var p Profile
+		if err := rows.Scan(&p.ChainID, &p.Address, &p.Username, &p.Bio, &p.ProfileImageHash, &p.IsActive); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, rows.Err()
+}
+
+func (t *sqlTx) RecordLog(ctx context.Context, chainID int64, contract common.Address, vLog types.Log) error {
+	_, err := t.exec(ctx, `
+	INSERT INTO indexed_logs (chain_id, contract_address, block_number, block_hash, tx_hash, log_index)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (chain_id, contract_address, block_number, log_index)
+	DO UPDATE SET block_hash = EXCLUDED.block_hash, tx_hash = EXCLUDED.tx_hash`,
+		chainID, contract.Hex(), vLog.BlockNumber, vLog.BlockHash.Hex(), vLog.TxHash.Hex(), vLog.Index,
+	)
+	if err != nil {
+		return fmt.Errorf("record indexed log: %w", err)
+	}
+	return nil
+}
+
+func (t *sqlTx) DeleteLogs(ctx context.Context, chainID int64, contract common.Address, from uint64) (int64, error) {
+	res, err := t.exec(ctx, `
+	DELETE FROM indexed_logs
+	WHERE chain_id = $1 AND contract_address = $2 AND block_number >= $3`,
+		chainID, contract.Hex(), from)
+	if err != nil {
+		return 0, fmt.Errorf("delete indexed logs: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func (t *sqlTx) LastLog(ctx context.Context, chainID int64, contract common.Address) (*Checkpoint, error) {
+	var (
+		last Checkpoint
+		hash string
+	)
+	err := t.queryRow(ctx, `
+	SELECT block_number, log_index, block_hash FROM indexed_logs
+	WHERE chain_id = $1 AND contract_address = $2
+	ORDER BY block_number DESC, log_index DESC
+	LIMIT 1`, chainID, contract.Hex()).Scan(&last.Block, &last.LogIndex, &hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load last indexed log: %w", err)
+	}
+	last.BlockHash = common.HexToHash(hash)
+	return &last, nil
+}
+
+func (t *sqlTx) SaveCheckpoint(ctx context.Context, chainID int64, contract common.Address, cp Checkpoint) error {
+	_, err := t.exec(ctx, `
+	INSERT INTO indexer_checkpoints (chain_id, contract_address, block_number, log_index, block_hash)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (chain_id, contract_address)
+	DO UPDATE SET block_number = EXCLUDED.block_number, log_index = EXCLUDED.log_index,
+		block_hash = EXCLUDED.block_hash, updated_at = CURRENT_TIMESTAMP`,
+		chainID, contract.Hex(), cp.Block, cp.LogIndex, cp.BlockHash.Hex(),
+	)
+	if err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (t *sqlTx) AdvanceCheckpoint(ctx context.Context, chainID int64, contract common.Address, cp Checkpoint) error {
+	_, err := t.exec(ctx, `
+	INSERT INTO indexer_checkpoints (chain_id, contract_address, block_number, log_index, block_hash)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (chain_id, contract_address)
+	DO UPDATE SET block_number = EXCLUDED.block_number, log_index = EXCLUDED.log_index,
+		block_hash = EXCLUDED.block_hash, updated_at = CURRENT_TIMESTAMP
+	WHERE (indexer_checkpoints.block_number, indexer_checkpoints.log_index) < (EXCLUDED.block_number, EXCLUDED.log_index)`,
+		chainID, contract.Hex(), cp.Block, cp.LogIndex, cp.BlockHash.Hex(),
+	)
+	if err != nil {
+		return fmt.Errorf("advance checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (t *sqlTx) DeleteCheckpoint(ctx context.Context, chainID int64, contract common.Address) error {
+	_, err := t.exec(ctx, `
+	DELETE FROM indexer_checkpoints WHERE chain_id = $1 AND contract_address = $2`,
+		chainID, contract.Hex(),
+	)
+	if err != nil {
+		return fmt.Errorf("delete checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (t *sqlTx) InsertDeal(ctx context.Context, d NewDeal, vLog types.Log) (bool, error) {
+	var token sql.NullString
+	if d.Token != nil {
+		token = sql.NullString{String: d.Token.Hex(), Valid: true}
+	}
+	var id int64
+	err := t.queryRow(ctx, `
+	INSERT INTO deals (chain_id, contract_address, client_address, freelancer_address, arbiter_address, total_amount,
+		token_address, project_description, status,
+		block_number, block_hash, tx_hash, log_index, confirmation_status)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	ON CONFLICT DO NOTHING
+	RETURNING id`,
+		d.ChainID,
+		d.Escrow.Hex(),
+		d.Client.Hex(),
+		d.Freelancer.Hex(),
+		d.Arbiter.Hex(),
+		d.TotalAmount.String(),
+		token,
+		d.ProjectDescription,
+		d.Status,
+		vLog.BlockNumber,
+		vLog.BlockHash.Hex(),
+		vLog.TxHash.Hex(),
+		vLog.Index,
+		d.ConfirmationStatus,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The log, or the escrow, is already stored.
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("insert deal: %w", err)
+	}
+
+	for role, user := range d.roles() {
+		_, err := t.exec(ctx, `
+		INSERT INTO user_escrows (chain_id, user_address, role, escrow_address, deal_id)
+		VALUES ($1, $2, $3, $4, $5)`,
+			d.ChainID, user.Hex(), role, d.Escrow.Hex(), id,
+		)
+		if err != nil {
+			return false, fmt.Errorf("index %s of %s: %w", role, d.Escrow.Hex(), err)
+		}
+	}
+	return true, nil
+}
+
+// roles maps each role in the deal to its participant. An unknown arbiter
+// is left out.
+func (d NewDeal) roles() map[string]common.Address {
+	roles := map[string]common.Address{"client": d.Client, "freelancer": d.Freelancer}
+	if d.Arbiter != (common.Address{}) {
+		roles["arbiter"] = d.Arbiter
+	}
+	return roles
+}
+
+func (t *sqlTx) ApplyDealEvent(ctx context.Context, e NewDealEvent, vLog types.Log) (bool, error) {
+	escrow := e.Escrow.Hex()
+	inserted, err := t.insert(ctx, `
+	INSERT INTO deal_events (chain_id, contract_address, event_name, actor_address, amount, work_submission, status,
+		block_number, block_hash, tx_hash, log_index)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	ON CONFLICT (chain_id, tx_hash, log_index) DO NOTHING`,
+		e.ChainID, escrow, e.Name, hexOrNull(e.Actor), bigOrNull(e.Amount), e.WorkSubmission, e.Status,
+		vLog.BlockNumber, vLog.BlockHash.Hex(), vLog.TxHash.Hex(), vLog.Index,
+	)
+	if err != nil || !inserted {
+		return false, wrap(err, "insert %s for %s", e.Name, escrow)
+	}
+	// The status follows the latest event, so an older event applied late
+	// doesn't move the deal backwards.
+	_, err = t.exec(ctx, `
+	UPDATE deals SET status = $1
+	WHERE chain_id = $2 AND contract_address = $3 AND NOT EXISTS (
+		SELECT 1 FROM deal_events e
+		WHERE e.chain_id = $2 AND e.contract_address = $3 AND (e.block_number, e.log_index) > ($4, $5)
+	)`, e.Status, e.ChainID, escrow, vLog.BlockNumber, vLog.Index)
+	if err != nil {
+		return false, fmt.Errorf("update deal status: %w", err)
+	}
+	return true, nil
+}
+
+func (t *sqlTx) RollbackDeals(ctx context.Context, chainID int64, from uint64) error {
+	// A deal's status is the one implied by its latest event, so deals that
+	// are about to lose events fall back to the newest one that remains.
+	_, err := t.exec(ctx, `
+	UPDATE deals SET status = COALESCE((
+		SELECT e.status FROM deal_events e
+		WHERE e.chain_id = deals.chain_id AND e.contract_address = deals.contract_address AND e.block_number < $2
+		ORDER BY e.block_number DESC, e.log_index DESC
+		LIMIT 1
+	), $3)
+	WHERE chain_id = $1
+		AND contract_address IN (SELECT contract_address FROM deal_events WHERE chain_id = $1 AND block_number >= $2)`,
+		chainID, from, DealCreated)
+	if err != nil {
+		return fmt.Errorf("recompute deal status: %w", err)
+	}
+	if _, err := t.exec(ctx, `DELETE FROM deal_events WHERE chain_id = $1 AND block_number >= $2`, chainID, from); err != nil {
+		return fmt.Errorf("delete deal events: %w", err)
+	}
+	if _, err := t.exec(ctx, `DELETE FROM deals WHERE chain_id = $1 AND block_number >= $2`, chainID, from); err != nil {
+		return fmt.Errorf("delete deals: %w", err)
+	}
+	return nil
+}
+
+// dealUpdate reads the deal at escrow for a DealUpdate about vLog.
+func (t *sqlTx) dealUpdate(ctx context.Context, chainID int64, escrow common.Address, event string, vLog types.Log) (*DealUpdate, error) {
+	u := DealUpdate{Event: event, ChainID: chainID, BlockNumber: int64(vLog.BlockNumber), TxHash: vLog.TxHash.Hex()}
+	err := t.queryRow(ctx, `
+	SELECT contract_address, client_address, freelancer_address, arbiter_address, status
+	FROM deals WHERE chain_id = $1 AND contract_address = $2`, chainID, escrow.Hex(),
+	).Scan(&u.Escrow, &u.Client, &u.Freelancer, &u.Arbiter, &u.Status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("notify %s: %w", event, err)
+	}
+	return &u, nil
+}
+
+func (t *sqlTx) ApplyRegistryEvent(ctx context.Context, e RegistryEvent, vLog types.Log) (bool, error) {
+	inserted, err := t.insert(ctx, `
+	INSERT INTO registry_events (chain_id, event_name, address, name, profile_hash, previous_address,
+		block_number, block_hash, tx_hash, log_index)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	ON CONFLICT (chain_id, tx_hash, log_index) DO NOTHING`,
+		e.ChainID, e.Name, e.Address.Hex(), e.ArbiterName, e.ProfileHash, hexOrNull(e.Previous),
+		vLog.BlockNumber, vLog.BlockHash.Hex(), vLog.TxHash.Hex(), vLog.Index,
+	)
+	if err != nil || !inserted {
+		return false, wrap(err, "insert %s", e.Name)
+	}
+
+	switch e.Name {
+	case "ArbiterAdded":
+		_, err = t.exec(ctx, `
+		INSERT INTO arbiters (chain_id, address, name, profile_hash, is_active, added_block, removed_block)
+		VALUES ($1, $2, $3, $4, TRUE, $5, NULL)
+		ON CONFLICT (chain_id, address)
+		DO UPDATE SET name = EXCLUDED.name, profile_hash = EXCLUDED.profile_hash, is_active = TRUE,
+			added_block = EXCLUDED.added_block, removed_block = NULL, updated_at = CURRENT_TIMESTAMP`,
+			e.ChainID, e.Address.Hex(), e.ArbiterName, e.ProfileHash, vLog.BlockNumber,
+		)
+		if err != nil {
+			return false, fmt.Errorf("upsert arbiter: %w", err)
+		}
+	case "ArbiterRemoved":
+		_, err = t.exec(ctx, `
+		UPDATE arbiters SET is_active = FALSE, removed_block = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE chain_id = $2 AND address = $3`,
+			vLog.BlockNumber, e.ChainID, e.Address.Hex(),
+		)
+		if err != nil {
+			return false, fmt.Errorf("deactivate arbiter: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// affectedArbiters selects the arbiters with registry events on chain $1
+// from block $2 onwards.
+const affectedArbiters = `
+	SELECT address FROM registry_events
+	WHERE chain_id = $1 AND block_number >= $2 AND event_name IN ('ArbiterAdded', 'ArbiterRemoved')`
+
+// rollbackArbiters deletes the arbiters touched from block from onwards,
+// runs rebuild (with the same arguments) to restore them from the events
+// that remain and then deletes the newer events.
+func (t *sqlTx) rollbackArbiters(ctx context.Context, chainID int64, from uint64, rebuild string) error {
+	if _, err := t.exec(ctx, `DELETE FROM arbiters WHERE chain_id = $1 AND address IN (`+affectedArbiters+`)`, chainID, from); err != nil {
+		return fmt.Errorf("delete arbiters: %w", err)
+	}
+	if _, err := t.exec(ctx, rebuild, chainID, from); err != nil {
+		return fmt.Errorf("rebuild arbiters: %w", err)
+	}
+	if _, err := t.exec(ctx, `DELETE FROM registry_events WHERE chain_id = $1 AND block_number >= $2`, chainID, from); err != nil {
+		return fmt.Errorf("delete registry events: %w", err)
+	}
+	return nil
+}
+
+func (t *sqlTx) ApplyProfileEvent(ctx context.Context, e ProfileEvent, vLog types.Log) (bool, error) {
+	switch e.Name {
+	case "ProfileUpdated":
+		inserted, err := t.insert(ctx, `
+		INSERT INTO profile_events (chain_id, event_name, address, username, bio, profile_image_hash,
+			block_number, block_hash, tx_hash, log_index)
+		VALUES ($1, 'ProfileUpdated', $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (chain_id, tx_hash, log_index) DO NOTHING`,
+			e.ChainID, e.Address.Hex(), e.Username, e.Bio, e.ProfileImageHash,
+			vLog.BlockNumber, vLog.BlockHash.Hex(), vLog.TxHash.Hex(), vLog.Index,
+		)
+		if err != nil || !inserted {
+			return false, wrap(err, "insert ProfileUpdated")
+		}
+		_, err = t.exec(ctx, `
+		INSERT INTO profiles (chain_id, address, username, bio, profile_image_hash, is_active, block_number)
+		VALUES ($1, $2, $3, $4, $5, TRUE, $6)
+		ON CONFLICT (chain_id, address)
+		DO UPDATE SET username = EXCLUDED.username, bio = EXCLUDED.bio, profile_image_hash = EXCLUDED.profile_image_hash,
+			is_active = TRUE, block_number = EXCLUDED.block_number, updated_at = CURRENT_TIMESTAMP`,
+			e.ChainID, e.Address.Hex(), e.Username, e.Bio, e.ProfileImageHash, vLog.BlockNumber,
+		)
+		if err != nil {
+			return false, fmt.Errorf("upsert profile: %w", err)
+		}
+	case "ProfileDeleted":
+		inserted, err := t.insert(ctx, `
+		INSERT INTO profile_events (chain_id, event_name, address, block_number, block_hash, tx_hash, log_index)
+		VALUES ($1, 'ProfileDeleted', $2, $3, $4, $5, $6)
+		ON CONFLICT (chain_id, tx_hash, log_index) DO NOTHING`,
+			e.ChainID, e.Address.Hex(), vLog.BlockNumber, vLog.BlockHash.Hex(), vLog.TxHash.Hex(), vLog.Index,
+		)
+		if err != nil || !inserted {
+			return false, wrap(err, "insert ProfileDeleted")
+		}
+		// deleteProfile can be called by an address that never set one, so
+		// the row is created if needed.
+		_, err = t.exec(ctx, `
+		INSERT INTO profiles (chain_id, address, username, bio, profile_image_hash, is_active, block_number)
+		VALUES ($1, $2, '', '', '', FALSE, $3)
+		ON CONFLICT (chain_id, address)
+		DO UPDATE SET is_active = FALSE, block_number = EXCLUDED.block_number, updated_at = CURRENT_TIMESTAMP`,
+			e.ChainID, e.Address.Hex(), vLog.BlockNumber,
+		)
+		if err != nil {
+			return false, fmt.Errorf("deactivate profile: %w", err)
+		}
+	default:
+		return false, fmt.Errorf("unknown profile event %s", e.Name)
+	}
+	return true, nil
+}
+
+// affectedProfiles selects the profiles with events on chain $1 from block
+// $2 onwards.
+const affectedProfiles = `SELECT address FROM profile_events WHERE chain_id = $1 AND block_number >= $2`
+
+// rollbackProfiles deletes the profiles touched from block from onwards,
+// runs rebuild (with the same arguments) to restore them from the events
+// that remain and then deletes the newer events.
+func (t *sqlTx) rollbackProfiles(ctx context.Context, chainID int64, from uint64, rebuild string) error {
+	if _, err := t.exec(ctx, `DELETE FROM profiles WHERE chain_id = $1 AND address IN (`+affectedProfiles+`)`, chainID, from); err != nil {
+		return fmt.Errorf("delete profiles: %w", err)
+	}
+	if _, err := t.exec(ctx, rebuild, chainID, from); err != nil {
+		return fmt.Errorf("rebuild profiles: %w", err)
+	}
+	if _, err := t.exec(ctx, `DELETE FROM profile_events WHERE chain_id = $1 AND block_number >= $2`, chainID, from); err != nil {
+		return fmt.Errorf("delete profile events: %w", err)
+	}
+	return nil
+}
+
+// insertTransfer stores a transfer and reports whether it is new; the
+// balances only move if it is.
+func (t *sqlTx) insertTransfer(ctx context.Context, tr Transfer, vLog types.Log) (bool, error) {
+	inserted, err := t.insert(ctx, `
+	INSERT INTO token_transfers (chain_id, token_address, from_address, to_address, amount,
+		block_number, block_hash, tx_hash, log_index)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (chain_id, tx_hash, log_index) DO NOTHING`,
+		tr.ChainID, tr.Token.Hex(), tr.From.Hex(), tr.To.Hex(), tr.Amount.String(),
+		vLog.BlockNumber, vLog.BlockHash.Hex(), vLog.TxHash.Hex(), vLog.Index,
+	)
+	return inserted, wrap(err, "insert transfer")
+}
+
+func (t *sqlTx) InsertApproval(ctx context.Context, a Approval, vLog types.Log) (bool, error) {
+	inserted, err := t.insert(ctx, `
+	INSERT INTO token_approvals (chain_id, token_address, owner_address, spender_address, amount,
+		block_number, block_hash, tx_hash, log_index)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (chain_id, tx_hash, log_index) DO NOTHING`,
+		a.ChainID, a.Token.Hex(), a.Owner.Hex(), a.Spender.Hex(), a.Amount.String(),
+		vLog.BlockNumber, vLog.BlockHash.Hex(), vLog.TxHash.Hex(), vLog.Index,
+	)
+	return inserted, wrap(err, "insert approval")
+}
+
+// deleteTokenEvents deletes the transfers and approvals of token from block
+// from onwards, once their balance changes have been reverted.
+func (t *sqlTx) deleteTokenEvents(ctx context.Context, chainID int64, token common.Address, from uint64) error {
+	if _, err := t.exec(ctx, `DELETE FROM token_transfers WHERE chain_id = $1 AND token_address = $2 AND block_number >= $3`, chainID, token.Hex(), from); err != nil {
+		return fmt.Errorf("delete transfers: %w", err)
+	}
+	if _, err := t.exec(ctx, `DELETE FROM token_approvals WHERE chain_id = $1 AND token_address = $2 AND block_number >= $3`, chainID, token.Hex(), from); err != nil {
+		return fmt.Errorf("delete approvals: %w", err)
+	}
+	return nil
+}
+
+func hexOrNull(addr *common.Address) sql.NullString {
+	if addr == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: addr.Hex(), Valid: true}
+}
+
+func bigOrNull(n *big.Int) sql.NullString {
+	if n == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: n.String(), Valid: true}
+}
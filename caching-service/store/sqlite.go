@@ -0,0 +1,237 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	_ "modernc.org/sqlite"
+)
+
+//go:embed sqlite_schema.sql
+var sqliteSchema string
+
+// SQLite is a Store in a single SQLite file (or ":memory:"), for local
+// development without a Postgres server. Deal updates only reach listeners
+// in the same process.
+type SQLite struct {
+	sqlStore
+	updates broadcaster
+}
+
+// OpenSQLite opens or creates the database at path and creates any missing
+// tables.
+func OpenSQLite(ctx context.Context, path string) (*SQLite, error) {
+	dsn := "file:" + path + "?" + url.Values{"_pragma": {
+		"foreign_keys(1)",
+		"busy_timeout(5000)",
+		"journal_mode(WAL)",
+	}}.Encode()
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	// SQLite allows one writer at a time, and a ":memory:" database exists
+	// per connection, so everything goes through one connection.
+	db.SetMaxOpenConns(1)
+	if _, err := db.ExecContext(ctx, sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create schema: %w", err)
+	}
+	return &SQLite{sqlStore: sqlStore{db: db, rebind: sqliteRebind}}, nil
+}
+
+var placeholder = regexp.MustCompile(`\$(\d+)`)
+
+// sqliteRebind turns Postgres' $1 placeholders into SQLite's ?1.
+func sqliteRebind(query string) string {
+	return placeholder.ReplaceAllString(query, "?$1")
+}
+
+func (s *SQLite) Begin(ctx context.Context) (Tx, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteTx{sqlTx: sqlTx{tx: tx, rebind: s.rebind}, store: s}, nil
+}
+
+func (s *SQLite) ListenDealUpdates(ctx context.Context, fn func(DealUpdate)) error {
+	return s.updates.listen(ctx, fn)
+}
+
+type sqliteTx struct {
+	sqlTx
+	store   *SQLite
+	pending []DealUpdate
+}
+
+func (t *sqliteTx) Commit() error {
+	if err := t.tx.Commit(); err != nil {
+		return err
+	}
+	t.store.updates.publish(t.pending)
+	return nil
+}
+
+func (t *sqliteTx) Rollback() error { return t.tx.Rollback() }
+
+func (t *sqliteTx) NotifyDealUpdate(ctx context.Context, chainID int64, escrow common.Address, event string, vLog types.Log) error {
+	u, err := t.dealUpdate(ctx, chainID, escrow, event, vLog)
+	if err != nil || u == nil {
+		return err
+	}
+	t.pending = append(t.pending, *u)
+	return nil
+}
+
+// SQLite has neither DISTINCT ON nor LATERAL, so the rebuilds pick the
+// latest events with correlated subqueries instead.
+
+func (t *sqliteTx) RollbackArbiters(ctx context.Context, chainID int64, from uint64) error {
+	// An arbiter is described by its latest ArbiterAdded, and is inactive if
+	// an ArbiterRemoved follows it.
+	return t.rollbackArbiters(ctx, chainID, from, `
+	INSERT INTO arbiters (chain_id, address, name, profile_hash, is_active, added_block, removed_block)
+	SELECT $1, a.address, a.name, a.profile_hash, r.block_number IS NULL, a.block_number, r.block_number
+	FROM registry_events a
+	LEFT JOIN registry_events r ON r.id = (
+		SELECT id FROM registry_events r
+		WHERE r.chain_id = $1 AND r.event_name = 'ArbiterRemoved' AND r.address = a.address AND r.block_number < $2
+			AND (r.block_number, r.log_index) > (a.block_number, a.log_index)
+		ORDER BY r.block_number DESC, r.log_index DESC
+		LIMIT 1
+	)
+	WHERE a.id IN (
+		SELECT (
+			SELECT id FROM registry_events
+			WHERE chain_id = $1 AND event_name = 'ArbiterAdded' AND address = x.address AND block_number < $2
+			ORDER BY block_number DESC, log_index DESC
+			LIMIT 1
+		)
+		FROM (`+affectedArbiters+`) x
+	)`)
+}
+
+func (t *sqliteTx) RollbackProfiles(ctx context.Context, chainID int64, from uint64) error {
+	// A profile holds the fields of its latest ProfileUpdated and is active
+	// unless a ProfileDeleted came after it.
+	return t.rollbackProfiles(ctx, chainID, from, `
+	INSERT INTO profiles (chain_id, address, username, bio, profile_image_hash, is_active, block_number)
+	SELECT $1, l.address, COALESCE(u.username, ''), COALESCE(u.bio, ''), COALESCE(u.profile_image_hash, ''),
+		l.event_name = 'ProfileUpdated', l.block_number
+	FROM profile_events l
+	LEFT JOIN profile_events u ON u.id = (
+		SELECT id FROM profile_events u
+		WHERE u.chain_id = $1 AND u.event_name = 'ProfileUpdated' AND u.address = l.address AND u.block_number < $2
+		ORDER BY u.block_number DESC, u.log_index DESC
+		LIMIT 1
+	)
+	WHERE l.id IN (
+		SELECT (
+			SELECT id FROM profile_events
+			WHERE chain_id = $1 AND address = x.address AND block_number < $2
+			ORDER BY block_number DESC, log_index DESC
+			LIMIT 1
+		)
+		FROM (`+affectedProfiles+`) x
+	)`)
+}
+
+func (t *sqliteTx) ApplyTransfer(ctx context.Context, tr Transfer, vLog types.Log) (bool, error) {
+	if inserted, err := t.insertTransfer(ctx, tr, vLog); err != nil || !inserted {
+		return false, err
+	}
+	if tr.From != (common.Address{}) {
+		if err := t.adjustBalance(ctx, tr.ChainID, tr.Token, tr.From, new(big.Int).Neg(tr.Amount)); err != nil {
+			return false, fmt.Errorf("debit %s: %w", tr.From.Hex(), err)
+		}
+	}
+	if tr.To != (common.Address{}) {
+		if err := t.adjustBalance(ctx, tr.ChainID, tr.Token, tr.To, tr.Amount); err != nil {
+			return false, fmt.Errorf("credit %s: %w", tr.To.Hex(), err)
+		}
+	}
+	return true, nil
+}
+
+func (t *sqliteTx) RollbackToken(ctx context.Context, chainID int64, token common.Address, from uint64) error {
+	rows, err := t.query(ctx, `
+	SELECT from_address, to_address, amount FROM token_transfers
+	WHERE chain_id = $1 AND token_address = $2 AND block_number >= $3`,
+		chainID, token.Hex(), from)
+	if err != nil {
+		return fmt.Errorf("load transfers: %w", err)
+	}
+	deltas := make(map[common.Address]*big.Int)
+	move := func(holder string, amount *big.Int) {
+		addr := common.HexToAddress(holder)
+		if addr == (common.Address{}) {
+			return
+		}
+		if deltas[addr] == nil {
+			deltas[addr] = new(big.Int)
+		}
+		deltas[addr].Add(deltas[addr], amount)
+	}
+	for rows.Next() {
+		var fromAddr, toAddr, raw string
+		if err := rows.Scan(&fromAddr, &toAddr, &raw); err != nil {
+			rows.Close()
+			return err
+		}
+		amount, ok := new(big.Int).SetString(raw, 10)
+		if !ok {
+			rows.Close()
+			return fmt.Errorf("invalid transfer amount %q", raw)
+		}
+		// Undo the transfer: the sender gets the amount back.
+		move(fromAddr, amount)
+		move(toAddr, new(big.Int).Neg(amount))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for holder, delta := range deltas {
+		if err := t.adjustBalance(ctx, chainID, token, holder, delta); err != nil {
+			return fmt.Errorf("revert balance of %s: %w", holder.Hex(), err)
+		}
+	}
+	return t.deleteTokenEvents(ctx, chainID, token, from)
+}
+
+// adjustBalance adds delta to holder's balance of token.
+func (t *sqliteTx) adjustBalance(ctx context.Context, chainID int64, token, holder common.Address, delta *big.Int) error {
+	var raw string
+	err := t.queryRow(ctx, `
+	SELECT balance FROM token_balances WHERE chain_id = $1 AND token_address = $2 AND holder_address = $3`,
+		chainID, token.Hex(), holder.Hex(),
+	).Scan(&raw)
+	balance := new(big.Int)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return err
+	default:
+		if _, ok := balance.SetString(raw, 10); !ok {
+			return fmt.Errorf("invalid balance %q", raw)
+		}
+	}
+	balance.Add(balance, delta)
+	_, err = t.exec(ctx, `
+	INSERT INTO token_balances (chain_id, token_address, holder_address, balance)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (chain_id, token_address, holder_address)
+	DO UPDATE SET balance = EXCLUDED.balance, updated_at = CURRENT_TIMESTAMP`,
+		chainID, token.Hex(), holder.Hex(), balance.String(),
+	)
+	return err
+}
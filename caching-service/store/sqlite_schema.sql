@@ -0,0 +1,207 @@
+-- The SQLite schema mirrors the Postgres one after every migration in
+-- db/migrations. SQLite databases are for development and tests, so there
+-- are no migrations: the schema is created if missing, and a database from
+-- an older schema has to be deleted.
+--
+-- Token amounts have no native 256-bit type here and are stored as decimal
+-- text; the store does the arithmetic.
+
+CREATE TABLE IF NOT EXISTS deals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chain_id INTEGER NOT NULL,
+    contract_address TEXT NOT NULL,
+    client_address TEXT NOT NULL,
+    freelancer_address TEXT NOT NULL,
+    arbiter_address TEXT NOT NULL,
+    total_amount TEXT NOT NULL,
+    token_address TEXT,
+    project_description TEXT,
+    status INTEGER DEFAULT 0,
+    confirmation_status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (confirmation_status IN ('pending', 'confirmed')),
+    block_number INTEGER,
+    block_hash TEXT,
+    tx_hash TEXT,
+    log_index INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (chain_id, contract_address)
+);
+
+CREATE INDEX IF NOT EXISTS deals_block_number_idx ON deals (chain_id, block_number);
+CREATE INDEX IF NOT EXISTS deals_pending_idx ON deals (chain_id, block_number) WHERE confirmation_status = 'pending';
+CREATE UNIQUE INDEX IF NOT EXISTS deals_log_key ON deals (chain_id, tx_hash, log_index);
+
+CREATE TABLE IF NOT EXISTS deal_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chain_id INTEGER NOT NULL,
+    contract_address TEXT NOT NULL,
+    event_name TEXT NOT NULL,
+    actor_address TEXT,
+    amount TEXT,
+    work_submission TEXT,
+    status INTEGER NOT NULL,
+    block_number INTEGER NOT NULL,
+    block_hash TEXT NOT NULL,
+    tx_hash TEXT NOT NULL,
+    log_index INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (chain_id, contract_address) REFERENCES deals (chain_id, contract_address) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS deal_events_contract_idx ON deal_events (chain_id, contract_address, block_number, log_index);
+CREATE INDEX IF NOT EXISTS deal_events_block_number_idx ON deal_events (chain_id, block_number);
+CREATE UNIQUE INDEX IF NOT EXISTS deal_events_log_key ON deal_events (chain_id, tx_hash, log_index);
+
+CREATE TABLE IF NOT EXISTS user_escrows (
+    chain_id INTEGER NOT NULL,
+    user_address TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('client', 'freelancer', 'arbiter')),
+    escrow_address TEXT NOT NULL,
+    deal_id INTEGER NOT NULL REFERENCES deals (id) ON DELETE CASCADE,
+    PRIMARY KEY (chain_id, user_address, role, escrow_address)
+);
+
+CREATE INDEX IF NOT EXISTS user_escrows_user_deal_idx ON user_escrows (user_address, deal_id DESC);
+CREATE INDEX IF NOT EXISTS user_escrows_user_role_deal_idx ON user_escrows (user_address, role, deal_id DESC);
+
+CREATE TABLE IF NOT EXISTS indexer_checkpoints (
+    chain_id INTEGER NOT NULL,
+    contract_address TEXT NOT NULL,
+    block_number INTEGER NOT NULL,
+    log_index INTEGER NOT NULL,
+    block_hash TEXT,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (chain_id, contract_address)
+);
+
+CREATE TABLE IF NOT EXISTS indexed_logs (
+    chain_id INTEGER NOT NULL,
+    contract_address TEXT NOT NULL,
+    block_number INTEGER NOT NULL,
+    block_hash TEXT NOT NULL,
+    tx_hash TEXT NOT NULL,
+    log_index INTEGER NOT NULL,
+    PRIMARY KEY (chain_id, contract_address, block_number, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS indexed_logs_block_hash_idx ON indexed_logs (block_hash);
+
+CREATE TABLE IF NOT EXISTS arbiters (
+    chain_id INTEGER NOT NULL,
+    address TEXT NOT NULL,
+    name TEXT NOT NULL,
+    profile_hash TEXT,
+    is_active BOOLEAN NOT NULL,
+    added_block INTEGER NOT NULL,
+    removed_block INTEGER,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (chain_id, address)
+);
+
+CREATE TABLE IF NOT EXISTS registry_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chain_id INTEGER NOT NULL,
+    event_name TEXT NOT NULL,
+    address TEXT NOT NULL,
+    name TEXT,
+    profile_hash TEXT,
+    previous_address TEXT,
+    block_number INTEGER NOT NULL,
+    block_hash TEXT NOT NULL,
+    tx_hash TEXT NOT NULL,
+    log_index INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS registry_events_address_idx ON registry_events (chain_id, address, block_number, log_index);
+CREATE INDEX IF NOT EXISTS registry_events_block_number_idx ON registry_events (chain_id, block_number);
+CREATE UNIQUE INDEX IF NOT EXISTS registry_events_log_key ON registry_events (chain_id, tx_hash, log_index);
+
+CREATE TABLE IF NOT EXISTS profiles (
+    chain_id INTEGER NOT NULL,
+    address TEXT NOT NULL,
+    username TEXT NOT NULL,
+    bio TEXT NOT NULL,
+    profile_image_hash TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL,
+    block_number INTEGER NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (chain_id, address)
+);
+
+CREATE TABLE IF NOT EXISTS profile_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chain_id INTEGER NOT NULL,
+    event_name TEXT NOT NULL,
+    address TEXT NOT NULL,
+    username TEXT,
+    bio TEXT,
+    profile_image_hash TEXT,
+    block_number INTEGER NOT NULL,
+    block_hash TEXT NOT NULL,
+    tx_hash TEXT NOT NULL,
+    log_index INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS profile_events_address_idx ON profile_events (chain_id, address, block_number, log_index);
+CREATE INDEX IF NOT EXISTS profile_events_block_number_idx ON profile_events (chain_id, block_number);
+CREATE UNIQUE INDEX IF NOT EXISTS profile_events_log_key ON profile_events (chain_id, tx_hash, log_index);
+
+CREATE VIEW IF NOT EXISTS deals_with_profiles AS
+SELECT d.*,
+    c.username AS client_username,
+    c.profile_image_hash AS client_avatar_hash,
+    f.username AS freelancer_username,
+    f.profile_image_hash AS freelancer_avatar_hash
+FROM deals d
+LEFT JOIN profiles c ON c.chain_id = d.chain_id AND c.address = d.client_address AND c.is_active
+LEFT JOIN profiles f ON f.chain_id = d.chain_id AND f.address = d.freelancer_address AND f.is_active;
+
+CREATE TABLE IF NOT EXISTS token_transfers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chain_id INTEGER NOT NULL,
+    token_address TEXT NOT NULL,
+    from_address TEXT NOT NULL,
+    to_address TEXT NOT NULL,
+    amount TEXT NOT NULL,
+    block_number INTEGER NOT NULL,
+    block_hash TEXT NOT NULL,
+    tx_hash TEXT NOT NULL,
+    log_index INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS token_transfers_from_idx ON token_transfers (from_address, block_number);
+CREATE INDEX IF NOT EXISTS token_transfers_to_idx ON token_transfers (to_address, block_number);
+CREATE INDEX IF NOT EXISTS token_transfers_token_block_idx ON token_transfers (chain_id, token_address, block_number);
+CREATE UNIQUE INDEX IF NOT EXISTS token_transfers_log_key ON token_transfers (chain_id, tx_hash, log_index);
+
+CREATE TABLE IF NOT EXISTS token_approvals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chain_id INTEGER NOT NULL,
+    token_address TEXT NOT NULL,
+    owner_address TEXT NOT NULL,
+    spender_address TEXT NOT NULL,
+    amount TEXT NOT NULL,
+    block_number INTEGER NOT NULL,
+    block_hash TEXT NOT NULL,
+    tx_hash TEXT NOT NULL,
+    log_index INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS token_approvals_owner_idx ON token_approvals (owner_address, spender_address, block_number);
+CREATE INDEX IF NOT EXISTS token_approvals_token_block_idx ON token_approvals (chain_id, token_address, block_number);
+CREATE UNIQUE INDEX IF NOT EXISTS token_approvals_log_key ON token_approvals (chain_id, tx_hash, log_index);
+
+CREATE TABLE IF NOT EXISTS token_balances (
+    chain_id INTEGER NOT NULL,
+    token_address TEXT NOT NULL,
+    holder_address TEXT NOT NULL,
+    balance TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (chain_id, token_address, holder_address)
+);
+
+CREATE INDEX IF NOT EXISTS token_balances_holder_idx ON token_balances (holder_address);
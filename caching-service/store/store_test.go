@@ -0,0 +1,308 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// The same scenario runs against every backend, so they stay
+// interchangeable. Postgres is only tested if POSTGRES_TEST_URL is set.
+func TestStores(t *testing.T) {
+	t.Run("memory", func(t *testing.T) { testStore(t, NewMemory()) })
+	t.Run("sqlite", func(t *testing.T) {
+		st, err := OpenSQLite(context.Background(), filepath.Join(t.TempDir(), "cache.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer st.Close()
+		testStore(t, st)
+	})
+	t.Run("postgres", func(t *testing.T) {
+		st, err := OpenPostgres(context.Background(), postgresURL(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer st.Close()
+		testStore(t, st)
+	})
+}
+
+// postgresURL returns the URL of a new, empty schema on the server at
+// POSTGRES_TEST_URL, skipping the test if it isn't set.
+func postgresURL(t *testing.T) string {
+	t.Helper()
+	base := os.Getenv("POSTGRES_TEST_URL")
+	if base == "" {
+		t.Skip("POSTGRES_TEST_URL is not set")
+	}
+	schema := fmt.Sprintf("store_test_%d", time.Now().UnixNano())
+	exec := func(query string) {
+		db, err := sql.Open("postgres", base)
+		if err == nil {
+			_, err = db.Exec(query)
+			db.Close()
+		}
+		if err != nil {
+			t.Errorf("%s: %v", query, err)
+		}
+	}
+	exec(`CREATE SCHEMA ` + schema)
+	t.Cleanup(func() { exec(`DROP SCHEMA ` + schema + ` CASCADE`) })
+
+	u, err := url.Parse(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+const chainID = 31337
+
+var (
+	factory    = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	escrow     = common.HexToAddress("0x94099942864EA81cCF197E9D71ac53310b1468D8")
+	client     = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	freelancer = common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+	arbiter    = common.HexToAddress("0x90F79bf6EB2c4f870365E785982E1f101E93b906")
+	token      = common.HexToAddress("0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0")
+)
+
+func logAt(block uint64, index uint) types.Log {
+	return types.Log{
+		BlockNumber: block,
+		Index:       index,
+		BlockHash:   common.BigToHash(big.NewInt(int64(block))),
+		TxHash:      common.BigToHash(big.NewInt(int64(block*1000) + int64(index))),
+	}
+}
+
+// write runs fn in a transaction and commits it.
+func write(t *testing.T, st Store, fn func(tx Tx) error) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := st.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testStore(t *testing.T, st Store) {
+	ctx := context.Background()
+	name := "Alice"
+
+	deal := NewDeal{
+		ChainID:            chainID,
+		Escrow:             escrow,
+		Client:             client,
+		Freelancer:         freelancer,
+		Arbiter:            arbiter,
+		TotalAmount:        big.NewInt(100),
+		Token:              &token,
+		ConfirmationStatus: StatusPending,
+	}
+	events := []NewDealEvent{
+		{ChainID: chainID, Escrow: escrow, Name: "AgreementFunded", Amount: big.NewInt(100), Status: 1},
+		{ChainID: chainID, Escrow: escrow, Name: "WorkSubmitted", Status: 2},
+	}
+	added := RegistryEvent{ChainID: chainID, Name: "ArbiterAdded", Address: arbiter, ArbiterName: &name}
+	minted := Transfer{ChainID: chainID, Token: token, To: client, Amount: big.NewInt(100)}
+	profile := ProfileEvent{ChainID: chainID, Name: "ProfileUpdated", Address: client, Username: "alice"}
+
+	// apply writes everything from blocks 10 and 11 and reports how many of
+	// the writes stored something new.
+	apply := func() (n int) {
+		write(t, st, func(tx Tx) error {
+			for _, fn := range []func() (bool, error){
+				func() (bool, error) { return tx.InsertDeal(ctx, deal, logAt(10, 0)) },
+				func() (bool, error) { return tx.ApplyRegistryEvent(ctx, added, logAt(10, 1)) },
+				func() (bool, error) { return tx.ApplyTransfer(ctx, minted, logAt(10, 2)) },
+				func() (bool, error) { return tx.ApplyDealEvent(ctx, events[0], logAt(11, 0)) },
+				func() (bool, error) { return tx.ApplyProfileEvent(ctx, profile, logAt(11, 1)) },
+			} {
+				inserted, err := fn()
+				if err != nil {
+					return err
+				}
+				if inserted {
+					n++
+				}
+			}
+			for _, vLog := range []types.Log{logAt(10, 0), logAt(11, 0)} {
+				if err := tx.RecordLog(ctx, chainID, factory, vLog); err != nil {
+					return err
+				}
+				if err := tx.AdvanceCheckpoint(ctx, chainID, factory, CheckpointOf(vLog)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		return n
+	}
+	if n := apply(); n != 5 {
+		t.Errorf("first pass stored %d events, want 5", n)
+	}
+	write(t, st, func(tx Tx) error {
+		vLog := logAt(12, 0)
+		if _, err := tx.ApplyDealEvent(ctx, events[1], vLog); err != nil {
+			return err
+		}
+		if err := tx.RecordLog(ctx, chainID, factory, vLog); err != nil {
+			return err
+		}
+		return tx.AdvanceCheckpoint(ctx, chainID, factory, CheckpointOf(vLog))
+	})
+	write(t, st, func(tx Tx) error {
+		_, err := tx.ApplyRegistryEvent(ctx, RegistryEvent{ChainID: chainID, Name: "ArbiterRemoved", Address: arbiter}, logAt(12, 1))
+		if err == nil {
+			_, err = tx.ApplyProfileEvent(ctx, ProfileEvent{ChainID: chainID, Name: "ProfileDeleted", Address: client}, logAt(12, 2))
+		}
+		return err
+	})
+
+	// Replaying blocks 10 and 11 stores nothing, doesn't move the deal back
+	// to funded and leaves the checkpoint at block 12.
+	if n := apply(); n != 0 {
+		t.Errorf("replay stored %d events, want 0", n)
+	}
+
+	// A rolled back transaction leaves nothing behind.
+	tx, err := st.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.ApplyDealEvent(ctx, NewDealEvent{ChainID: chainID, Escrow: escrow, Name: "WorkApproved", Status: 3}, logAt(13, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := onlyDeal(t, st, DealQuery{User: client})
+	if got.Status != 2 || got.ClientUsername != nil || got.Token == nil || *got.Token != token.Hex() {
+		t.Errorf("deal = %+v, want status 2 with token and without a client profile", got)
+	}
+	if deals, err := st.Deals(ctx, DealQuery{User: freelancer, Role: "client"}); err != nil || len(deals) != 0 {
+		t.Errorf("freelancer as client: got %d deals, %v", len(deals), err)
+	}
+	if events, err := st.DealEvents(ctx, chainID, escrow); err != nil || len(events) != 2 || events[0].Name != "AgreementFunded" || events[0].Escrow != escrow.Hex() {
+		t.Errorf("events = %+v, %v", events, err)
+	}
+	if events, err := st.DealEvents(ctx, chainID); err != nil || len(events) != 0 {
+		t.Errorf("events of no deals = %+v, %v", events, err)
+	}
+	if a := onlyArbiter(t, st); a.IsActive || a.RemovedBlock == nil || *a.RemovedBlock != 12 {
+		t.Errorf("arbiter = %+v, want removed at block 12", a)
+	}
+	if cp, err := st.Checkpoint(ctx, chainID, factory); err != nil || cp == nil || cp.Block != 12 {
+		t.Errorf("checkpoint = %+v, %v", cp, err)
+	}
+	if blocks, err := st.IngestedBlocks(ctx, chainID, factory, 12); err != nil || len(blocks) != 2 || blocks[0].Number != 11 {
+		t.Errorf("ingested blocks below 12 = %+v, %v", blocks, err)
+	}
+	if n, err := st.ConfirmDeals(ctx, chainID, 10); err != nil || n != 1 {
+		t.Errorf("confirmed %d deals, %v", n, err)
+	}
+
+	// Rolling back block 12 restores the state after block 11.
+	write(t, st, func(tx Tx) error {
+		for _, rollback := range []func(context.Context, int64, uint64) error{tx.RollbackDeals, tx.RollbackArbiters, tx.RollbackProfiles} {
+			if err := rollback(ctx, chainID, 12); err != nil {
+				return err
+			}
+		}
+		if err := tx.RollbackToken(ctx, chainID, token, 12); err != nil {
+			return err
+		}
+		if n, err := tx.DeleteLogs(ctx, chainID, factory, 12); err != nil || n != 1 {
+			t.Errorf("deleted %d logs, %v", n, err)
+		}
+		cp, err := tx.LastLog(ctx, chainID, factory)
+		if err != nil || cp == nil || cp.Block != 11 {
+			t.Errorf("last log = %+v, %v", cp, err)
+		}
+		return tx.SaveCheckpoint(ctx, chainID, factory, *cp)
+	})
+	got = onlyDeal(t, st, DealQuery{Escrow: escrow})
+	if got.Status != 1 || got.ConfirmationStatus != StatusConfirmed || got.ClientUsername == nil || *got.ClientUsername != "alice" {
+		t.Errorf("deal = %+v, want confirmed, funded and with alice's profile", got)
+	}
+	if a := onlyArbiter(t, st); !a.IsActive || a.Name != name {
+		t.Errorf("arbiter = %+v, want active", a)
+	}
+	if profiles, err := st.Profiles(ctx, ProfileQuery{ChainID: chainID, Addresses: []common.Address{freelancer, client}}); err != nil || len(profiles) != 1 || profiles[0].Username != "alice" {
+		t.Errorf("profiles of the client and freelancer = %+v, %v", profiles, err)
+	}
+	if arbiters, err := st.Arbiters(ctx, ArbiterQuery{Addresses: []common.Address{client, arbiter}}); err != nil || len(arbiters) != 1 {
+		t.Errorf("arbiters among the client and arbiter = %+v, %v", arbiters, err)
+	}
+	if ok, err := st.IngestedBlock(ctx, chainID, factory, logAt(12, 0).BlockHash); err != nil || ok {
+		t.Errorf("block 12 still ingested: %v, %v", ok, err)
+	}
+
+	// Rolling back everything empties the store.
+	write(t, st, func(tx Tx) error {
+		for _, rollback := range []func(context.Context, int64, uint64) error{tx.RollbackDeals, tx.RollbackArbiters, tx.RollbackProfiles} {
+			if err := rollback(ctx, chainID, 10); err != nil {
+				return err
+			}
+		}
+		return tx.DeleteCheckpoint(ctx, chainID, factory)
+	})
+	if deals, err := st.Deals(ctx, DealQuery{}); err != nil || len(deals) != 0 {
+		t.Errorf("got %d deals after a full rollback, %v", len(deals), err)
+	}
+	if arbiters, err := st.Arbiters(ctx, ArbiterQuery{}); err != nil || len(arbiters) != 0 {
+		t.Errorf("got %d arbiters after a full rollback, %v", len(arbiters), err)
+	}
+	if profiles, err := st.Profiles(ctx, ProfileQuery{}); err != nil || len(profiles) != 0 {
+		t.Errorf("got %d profiles after a full rollback, %v", len(profiles), err)
+	}
+	if cp, err := st.Checkpoint(ctx, chainID, factory); err != nil || cp != nil {
+		t.Errorf("checkpoint = %+v, %v", cp, err)
+	}
+}
+
+func onlyDeal(t *testing.T, st Store, q DealQuery) Deal {
+	t.Helper()
+	deals, err := st.Deals(context.Background(), q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deals) != 1 {
+		t.Fatalf("got %d deals, want 1", len(deals))
+	}
+	return deals[0]
+}
+
+func onlyArbiter(t *testing.T, st Store) Arbiter {
+	t.Helper()
+	arbiters, err := st.Arbiters(context.Background(), ArbiterQuery{ChainID: chainID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(arbiters) != 1 {
+		t.Fatalf("got %d arbiters, want 1", len(arbiters))
+	}
+	return arbiters[0]
+}
@@ -0,0 +1,15 @@
+// Code generated by cmd/bindgen - DO NOT EDIT.
+
+package userprofile
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nikhil-inja/decentralized-escrow-service/caching-service/bindcheck"
+)
+
+// Artifact describes the Hardhat artifact this binding was generated from.
+var Artifact = bindcheck.Artifact{
+	Contract:             "contracts/UserProfile.sol:UserProfile",
+	DeployedBytecodeHash: common.HexToHash("0x5e087ee9254a7689565a7d04b3e9d66b92a21cc113bb23f3cd58694666c7c396"),
+	ImmutableRanges:      []bindcheck.Range{},
+}
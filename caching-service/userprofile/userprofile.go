@@ -0,0 +1,608 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package userprofile
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// UserProfileProfile is an auto generated low-level Go binding around an user-defined struct.
+type UserProfileProfile struct {
+	Username         string
+	Bio              string
+	ProfileImageHash string
+	IsActive         bool
+}
+
+// BindingsMetaData contains all meta data concerning the Bindings contract.
+var BindingsMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"}],\"name\":\"ProfileDeleted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"username\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"bio\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"profileImageHash\",\"type\":\"string\"}],\"name\":\"ProfileUpdated\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"deleteProfile\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_user\",\"type\":\"address\"}],\"name\":\"getProfile\",\"outputs\":[{\"components\":[{\"internalType\":\"string\",\"name\":\"username\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"bio\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"profileImageHash\",\"type\":\"string\"},{\"internalType\":\"bool\",\"name\":\"isActive\",\"type\":\"bool\"}],\"internalType\":\"structUserProfile.Profile\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"profiles\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"username\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"bio\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"profileImageHash\",\"type\":\"string\"},{\"internalType\":\"bool\",\"name\":\"isActive\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_username\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"_bio\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"_profileImageHash\",\"type\":\"string\"}],\"name\":\"setProfile\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// BindingsABI is the input ABI used to generate the binding from.
+// Deprecated: Use BindingsMetaData.ABI instead.
+var BindingsABI = BindingsMetaData.ABI
+
+// Bindings is an auto generated Go binding around an Ethereum contract.
+type Bindings struct {
+	BindingsCaller     // Read-only binding to the contract
+	BindingsTransactor // Write-only binding to the contract
+	BindingsFilterer   // Log filterer for contract events
+}
+
+// BindingsCaller is an auto generated read-only Go binding around an Ethereum contract.
+type BindingsCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BindingsTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type BindingsTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BindingsFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type BindingsFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BindingsSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type BindingsSession struct {
+	Contract     *Bindings         // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BindingsCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type BindingsCallerSession struct {
+	Contract *BindingsCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts   // Call options to use throughout this session
+}
+
+// BindingsTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type BindingsTransactorSession struct {
+	Contract     *BindingsTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts   // Transaction auth options to use throughout this session
+}
+
+// BindingsRaw is an auto generated low-level Go binding around an Ethereum contract.
+type BindingsRaw struct {
+	Contract *Bindings // Generic contract binding to access the raw methods on
+}
+
+// BindingsCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type BindingsCallerRaw struct {
+	Contract *BindingsCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// BindingsTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type BindingsTransactorRaw struct {
+	Contract *BindingsTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBindings creates a new instance of Bindings, bound to a specific deployed contract.
+func NewBindings(address common.Address, backend bind.ContractBackend) (*Bindings, error) {
+	contract, err := bindBindings(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Bindings{BindingsCaller: BindingsCaller{contract: contract}, BindingsTransactor: BindingsTransactor{contract: contract}, BindingsFilterer: BindingsFilterer{contract: contract}}, nil
+}
+
+// NewBindingsCaller creates a new read-only instance of Bindings, bound to a specific deployed contract.
+func NewBindingsCaller(address common.Address, caller bind.ContractCaller) (*BindingsCaller, error) {
+	contract, err := bindBindings(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsCaller{contract: contract}, nil
+}
+
+// NewBindingsTransactor creates a new write-only instance of Bindings, bound to a specific deployed contract.
+func NewBindingsTransactor(address common.Address, transactor bind.ContractTransactor) (*BindingsTransactor, error) {
+	contract, err := bindBindings(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsTransactor{contract: contract}, nil
+}
+
+// NewBindingsFilterer creates a new log filterer instance of Bindings, bound to a specific deployed contract.
+func NewBindingsFilterer(address common.Address, filterer bind.ContractFilterer) (*BindingsFilterer, error) {
+	contract, err := bindBindings(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsFilterer{contract: contract}, nil
+}
+
+// bindBindings binds a generic wrapper to an already deployed contract.
+func bindBindings(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := BindingsMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bindings *BindingsRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bindings.Contract.BindingsCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bindings *BindingsRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bindings.Contract.BindingsTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bindings *BindingsRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bindings.Contract.BindingsTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bindings *BindingsCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bindings.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bindings *BindingsTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bindings.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bindings *BindingsTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bindings.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetProfile is a free data retrieval call binding the contract method 0x0f53a470.
+//
+// Solidity: function getProfile(address _user) view returns((string,string,string,bool))
+func (_Bindings *BindingsCaller) GetProfile(opts *bind.CallOpts, _user common.Address) (UserProfileProfile, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "getProfile", _user)
+
+	if err != nil {
+		return *new(UserProfileProfile), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(UserProfileProfile)).(*UserProfileProfile)
+
+	return out0, err
+
+}
+
+// GetProfile is a free data retrieval call binding the contract method 0x0f53a470.
+//
+// Solidity: function getProfile(address _user) view returns((string,string,string,bool))
+func (_Bindings *BindingsSession) GetProfile(_user common.Address) (UserProfileProfile, error) {
+	return _Bindings.Contract.GetProfile(&_Bindings.CallOpts, _user)
+}
+
+// GetProfile is a free data retrieval call binding the contract method 0x0f53a470.
+//
+// Solidity: function getProfile(address _user) view returns((string,string,string,bool))
+func (_Bindings *BindingsCallerSession) GetProfile(_user common.Address) (UserProfileProfile, error) {
+	return _Bindings.Contract.GetProfile(&_Bindings.CallOpts, _user)
+}
+
+// Profiles is a free data retrieval call binding the contract method 0xbbe15627.
+//
+// Solidity: function profiles(address ) view returns(string username, string bio, string profileImageHash, bool isActive)
+func (_Bindings *BindingsCaller) Profiles(opts *bind.CallOpts, arg0 common.Address) (struct {
+	Username         string
+	Bio              string
+	ProfileImageHash string
+	IsActive         bool
+}, error) {
+	var out []interface{}
+	err := _Bindings.contract.Call(opts, &out, "profiles", arg0)
+
+	outstruct := new(struct {
+		Username         string
+		Bio              string
+		ProfileImageHash string
+		IsActive         bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Username = *abi.ConvertType(out[0], new(string)).(*string)
+	outstruct.Bio = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.ProfileImageHash = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.IsActive = *abi.ConvertType(out[3], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// Profiles is a free data retrieval call binding the contract method 0xbbe15627.
+//
+// Solidity: function profiles(address ) view returns(string username, string bio, string profileImageHash, bool isActive)
+func (_Bindings *BindingsSession) Profiles(arg0 common.Address) (struct {
+	Username         string
+	Bio              string
+	ProfileImageHash string
+	IsActive         bool
+}, error) {
+	return _Bindings.Contract.Profiles(&_Bindings.CallOpts, arg0)
+}
+
+// Profiles is a free data retrieval call binding the contract method 0xbbe15627.
+//
+// Solidity: function profiles(address ) view returns(string username, string bio, string profileImageHash, bool isActive)
+func (_Bindings *BindingsCallerSession) Profiles(arg0 common.Address) (struct {
+	Username         string
+	Bio              string
+	ProfileImageHash string
+	IsActive         bool
+}, error) {
+	return _Bindings.Contract.Profiles(&_Bindings.CallOpts, arg0)
+}
+
+// DeleteProfile is a paid mutator transaction binding the contract method 0x6d4540eb.
+//
+// Solidity: function deleteProfile() returns()
+func (_Bindings *BindingsTransactor) DeleteProfile(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "deleteProfile")
+}
+
+// DeleteProfile is a paid mutator transaction binding the contract method 0x6d4540eb.
+//
+// Solidity: function deleteProfile() returns()
+func (_Bindings *BindingsSession) DeleteProfile() (*types.Transaction, error) {
+	return _Bindings.Contract.DeleteProfile(&_Bindings.TransactOpts)
+}
+
+// DeleteProfile is a paid mutator transaction binding the contract method 0x6d4540eb.
+//
+// Solidity: function deleteProfile() returns()
+func (_Bindings *BindingsTransactorSession) DeleteProfile() (*types.Transaction, error) {
+	return _Bindings.Contract.DeleteProfile(&_Bindings.TransactOpts)
+}
+
+// SetProfile is a paid mutator transaction binding the contract method 0x52640314.
+//
+// Solidity: function setProfile(string _username, string _bio, string _profileImageHash) returns()
+func (_Bindings *BindingsTransactor) SetProfile(opts *bind.TransactOpts, _username string, _bio string, _profileImageHash string) (*types.Transaction, error) {
+	return _Bindings.contract.Transact(opts, "setProfile", _username, _bio, _profileImageHash)
+}
+
+// SetProfile is a paid mutator transaction binding the contract method 0x52640314.
+//
+// Solidity: function setProfile(string _username, string _bio, string _profileImageHash) returns()
+func (_Bindings *BindingsSession) SetProfile(_username string, _bio string, _profileImageHash string) (*types.Transaction, error) {
+	return _Bindings.Contract.SetProfile(&_Bindings.TransactOpts, _username, _bio, _profileImageHash)
+}
+
+// SetProfile is a paid mutator transaction binding the contract method 0x52640314.
+//
+// Solidity: function setProfile(string _username, string _bio, string _profileImageHash) returns()
+func (_Bindings *BindingsTransactorSession) SetProfile(_username string, _bio string, _profileImageHash string) (*types.Transaction, error) {
+	return _Bindings.Contract.SetProfile(&_Bindings.TransactOpts, _username, _bio, _profileImageHash)
+}
+
+// BindingsProfileDeletedIterator is returned from FilterProfileDeleted and is used to iterate over the raw logs and unpacked data for ProfileDeleted events raised by the Bindings contract.
+type BindingsProfileDeletedIterator struct {
+	Event *BindingsProfileDeleted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BindingsProfileDeletedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BindingsProfileDeleted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BindingsProfileDeleted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BindingsProfileDeletedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BindingsProfileDeletedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BindingsProfileDeleted represents a ProfileDeleted event raised by the Bindings contract.
+type BindingsProfileDeleted struct {
+	User common.Address
+	Raw  types.Log // Blockchain specific contextual infos
+}
+
+// FilterProfileDeleted is a free log retrieval operation binding the contract event 0x517b492f6c7db035d7c3c4fa1ce0cb2d05e1f24219256f50fbab78242e544c04.
+//
+// Solidity: event ProfileDeleted(address indexed user)
+func (_Bindings *BindingsFilterer) FilterProfileDeleted(opts *bind.FilterOpts, user []common.Address) (*BindingsProfileDeletedIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _Bindings.contract.FilterLogs(opts, "ProfileDeleted", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsProfileDeletedIterator{contract: _Bindings.contract, event: "ProfileDeleted", logs: logs, sub: sub}, nil
+}
+
+// WatchProfileDeleted is a free log subscription operation binding the contract event 0x517b492f6c7db035d7c3c4fa1ce0cb2d05e1f24219256f50fbab78242e544c04.
+//
+// Solidity: event ProfileDeleted(address indexed user)
+func (_Bindings *BindingsFilterer) WatchProfileDeleted(opts *bind.WatchOpts, sink chan<- *BindingsProfileDeleted, user []common.Address) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _Bindings.contract.WatchLogs(opts, "ProfileDeleted", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BindingsProfileDeleted)
+				if err := _Bindings.contract.UnpackLog(event, "ProfileDeleted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseProfileDeleted is a log parse operation binding the contract event 0x517b492f6c7db035d7c3c4fa1ce0cb2d05e1f24219256f50fbab78242e544c04.
+//
+// Solidity: event ProfileDeleted(address indexed user)
+func (_Bindings *BindingsFilterer) ParseProfileDeleted(log types.Log) (*BindingsProfileDeleted, error) {
+	event := new(BindingsProfileDeleted)
+	if err := _Bindings.contract.UnpackLog(event, "ProfileDeleted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BindingsProfileUpdatedIterator is returned from FilterProfileUpdated and is used to iterate over the raw logs and unpacked data for ProfileUpdated events raised by the Bindings contract.
+type BindingsProfileUpdatedIterator struct {
+	Event *BindingsProfileUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BindingsProfileUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BindingsProfileUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BindingsProfileUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BindingsProfileUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BindingsProfileUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BindingsProfileUpdated represents a ProfileUpdated event raised by the Bindings contract.
+type BindingsProfileUpdated struct {
+	User             common.Address
+	Username         string
+	Bio              string
+	ProfileImageHash string
+	Raw              types.Log // Blockchain specific contextual infos
+}
+
+// FilterProfileUpdated is a free log retrieval operation binding the contract event 0x6420daf1b58438e85465ef21dee378d307a509caab491149e3f066c5caf1266d.
+//
+// Solidity: event ProfileUpdated(address indexed user, string username, string bio, string profileImageHash)
+func (_Bindings *BindingsFilterer) FilterProfileUpdated(opts *bind.FilterOpts, user []common.Address) (*BindingsProfileUpdatedIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _Bindings.contract.FilterLogs(opts, "ProfileUpdated", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BindingsProfileUpdatedIterator{contract: _Bindings.contract, event: "ProfileUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchProfileUpdated is a free log subscription operation binding the contract event 0x6420daf1b58438e85465ef21dee378d307a509caab491149e3f066c5caf1266d.
+//
+// Solidity: event ProfileUpdated(address indexed user, string username, string bio, string profileImageHash)
+func (_Bindings *BindingsFilterer) WatchProfileUpdated(opts *bind.WatchOpts, sink chan<- *BindingsProfileUpdated, user []common.Address) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _Bindings.contract.WatchLogs(opts, "ProfileUpdated", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BindingsProfileUpdated)
+				if err := _Bindings.contract.UnpackLog(event, "ProfileUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseProfileUpdated is a log parse operation binding the contract event 0x6420daf1b58438e85465ef21dee378d307a509caab491149e3f066c5caf1266d.
+//
+// Solidity: event ProfileUpdated(address indexed user, string username, string bio, string profileImageHash)
+func (_Bindings *BindingsFilterer) ParseProfileUpdated(log types.Log) (*BindingsProfileUpdated, error) {
+	event := new(BindingsProfileUpdated)
+	if err := _Bindings.contract.UnpackLog(event, "ProfileUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}